@@ -2,9 +2,4 @@ module github.com/naughtyGitCat/anonymous-query-scan
 
 go 1.22
 
-require (
-	github.com/go-sql-driver/mysql v1.8.1
-	github.com/mattn/go-sqlite3 v1.14.22
-)
-
-require filippo.io/edwards25519 v1.1.0 // indirect
+require github.com/mattn/go-sqlite3 v1.14.22
@@ -0,0 +1,142 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"encoding/hex"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BinaryEncoding how values of binary columns (BINARY, VARBINARY, BLOB family) are marshaled into json
+// go string holds arbitrary bytes, so scanning into *string loses nothing, but marshaling such string replaces invalid utf-8
+type BinaryEncoding int
+
+const (
+	// BinaryAsBase64 convert to []byte, marshaled as base64 string like "AQID"
+	BinaryAsBase64 BinaryEncoding = iota
+	// BinaryAsHex convert to HexBytes, marshaled as hex string like "010203"
+	BinaryAsHex
+	// BinaryAsArray convert to ByteArray, marshaled as integer array like [1,2,3]
+	BinaryAsArray
+)
+
+// binaryMySQLTypes database type names of binary columns reported by go-sql-driver
+var binaryMySQLTypes = []string{"BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB"}
+
+// HexBytes bytes marshaled as hex string
+type HexBytes []byte
+
+// MarshalJSON marshal bytes as hex string
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(hex.EncodeToString(b))), nil
+}
+
+// UnmarshalJSON accept hex string, null is a no-op like encoding/json does
+func (b *HexBytes) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+	v, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	*b = v
+	return nil
+}
+
+// ByteArray bytes marshaled as integer array, see TestUint8Marshal for why []byte is not
+type ByteArray []byte
+
+// MarshalJSON marshal bytes as integer array
+func (b ByteArray) MarshalJSON() ([]byte, error) {
+	if b == nil {
+		return []byte("null"), nil
+	}
+	var builder strings.Builder
+	builder.Grow(len(b)*4 + 2)
+	builder.WriteByte('[')
+	for i, v := range b {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(strconv.Itoa(int(v)))
+	}
+	builder.WriteByte(']')
+	return []byte(builder.String()), nil
+}
+
+// BinaryConverters converters of all binary column types with given json encoding, regardless of WithBinaryEncoding
+// built-in converters follow WithBinaryEncoding, override a registry with these to fix the encoding of its scans
+//
+//	registry := DefaultRegistry.Clone()
+//	registry.Override(BinaryConverters(BinaryAsHex)...)
+func BinaryConverters(encoding BinaryEncoding) []Converter {
+	replaceFunc, valueType := binaryReplaceFunc(encoding)
+	converters := make([]Converter, 0, len(binaryMySQLTypes))
+	for _, mysqlType := range binaryMySQLTypes {
+		converters = append(converters, Converter{
+			Name:        "handle " + mysqlType,
+			ScanType:    reflect.TypeOf(sql.RawBytes{}),
+			MySQLType:   mysqlType,
+			ValueType:   valueType,
+			ReplaceFunc: replaceFunc,
+		})
+	}
+	return converters
+}
+
+// binaryConverters built-in converters of binary column types, encoding follows WithBinaryEncoding, raw *string under ValueAsPointer
+func binaryConverters() []Converter {
+	converters := make([]Converter, 0, len(binaryMySQLTypes))
+	for _, mysqlType := range binaryMySQLTypes {
+		converters = append(converters, Converter{
+			Name:      "handle " + mysqlType,
+			ScanType:  reflect.TypeOf(sql.RawBytes{}),
+			MySQLType: mysqlType,
+			Configure: func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+				// binary columns had no converter before ValueMode introduced
+				if opts.ValueMode == ValueAsPointer {
+					return convertStringPointer, reflect.TypeOf((*string)(nil))
+				}
+				return binaryReplaceFunc(opts.BinaryEncoding)
+			},
+		})
+	}
+	return converters
+}
+
+// binaryReplaceFunc replace func and value type of binary columns with given json encoding
+func binaryReplaceFunc(encoding BinaryEncoding) (func(*string) (any, error), reflect.Type) {
+	switch encoding {
+	case BinaryAsHex:
+		return func(in *string) (any, error) {
+			if in == nil {
+				return nil, nil
+			}
+			return HexBytes(*in), nil
+		}, reflect.TypeOf(HexBytes(nil))
+	case BinaryAsArray:
+		return func(in *string) (any, error) {
+			if in == nil {
+				return nil, nil
+			}
+			return ByteArray(*in), nil
+		}, reflect.TypeOf(ByteArray(nil))
+	default:
+		return func(in *string) (any, error) {
+			if in == nil {
+				return nil, nil
+			}
+			return []byte(*in), nil
+		}, reflect.TypeOf([]byte(nil))
+	}
+}
@@ -0,0 +1,119 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestBinaryConverters
+// non utf-8 bytes 0xff 0x00 0x01 marshaled by each encoding without mangling
+func TestBinaryConverters(t *testing.T) {
+	tests := []struct {
+		encoding BinaryEncoding
+		expect   string
+	}{
+		{BinaryAsBase64, "\"/wAB\""},
+		{BinaryAsHex, "\"ff0001\""},
+		{BinaryAsArray, "[255,0,1]"},
+	}
+	for _, test := range tests {
+		registry := DefaultRegistry.Clone()
+		if err := registry.Override(BinaryConverters(test.encoding)...); err != nil {
+			t.Fatalf("Override() failed: %v", err)
+		}
+		for _, mysqlType := range binaryMySQLTypes {
+			converter, ok := registry.Lookup(mysqlType)
+			if !ok {
+				t.Fatalf("no converter for %s", mysqlType)
+			}
+			in := "\xff\x00\x01"
+			v, err := converter.ReplaceFunc(&in)
+			if err != nil {
+				t.Errorf("%s convert failed: %v", mysqlType, err)
+				continue
+			}
+			bytes, err := json.Marshal(v)
+			if err != nil {
+				t.Errorf("json.Marshal() failed: %v", err)
+			}
+			if string(bytes) != test.expect {
+				t.Errorf("%s encoding %d expect %s, got %s", mysqlType, test.encoding, test.expect, bytes)
+			}
+		}
+	}
+}
+
+// TestScanBinaryColumn
+// sql rows: [x'ff0001']
+// expect result: [["ff0001"]] with hex encoding
+func TestScanBinaryColumn(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "content", DatabaseType: "VARBINARY"}},
+		Rows:    [][]any{{"\xff\x00\x01"}},
+	})
+	registry := DefaultRegistry.Clone()
+	if err := registry.Override(BinaryConverters(BinaryAsHex)...); err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	anonymousRows, err := ScanAnonymousRowsWithRegistry(rows, registry)
+	if err != nil {
+		t.Fatalf("ScanAnonymousRowsWithRegistry() failed: %v", err)
+	}
+	bytes, err := json.Marshal(anonymousRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const rawJson = "[[\"ff0001\"]]"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+}
+
+// TestBinaryEncodingOption
+// sql rows: [x'ff0001']
+// expect result: encoding selected per scan by WithBinaryEncoding with DefaultRegistry
+func TestBinaryEncodingOption(t *testing.T) {
+	tests := []struct {
+		encoding BinaryEncoding
+		expect   string
+	}{
+		{BinaryAsBase64, "[[\"/wAB\"]]"},
+		{BinaryAsHex, "[[\"ff0001\"]]"},
+		{BinaryAsArray, "[[[255,0,1]]]"},
+	}
+	for _, test := range tests {
+		rows := queryFake(t, mysqltest.ResultSet{
+			Columns: []mysqltest.Column{{Name: "content", DatabaseType: "BLOB"}},
+			Rows:    [][]any{{"\xff\x00\x01"}},
+		})
+		allRows, err := Scan(rows, WithBinaryEncoding(test.encoding))
+		if err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		bytes, err := json.Marshal(allRows)
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		}
+		if string(bytes) != test.expect {
+			t.Errorf("encoding %d expect %s, got %s", test.encoding, test.expect, bytes)
+		}
+	}
+}
+
+// TestHexBytesUnmarshal
+// hex string decoded into bytes, null keeps value like encoding/json does for other types
+func TestHexBytesUnmarshal(t *testing.T) {
+	var b HexBytes
+	if err := json.Unmarshal([]byte("\"ff0001\""), &b); err != nil || string(b) != "\xff\x00\x01" {
+		t.Errorf("json.Unmarshal() got %v %v", b, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &b); err != nil || string(b) != "\xff\x00\x01" {
+		t.Errorf("json.Unmarshal() of null got %v %v", b, err)
+	}
+}
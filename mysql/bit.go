@@ -0,0 +1,76 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// BitMode how BIT values are converted
+type BitMode int
+
+const (
+	// BitAsUint64 convert to uint64, BIT(1) to BIT(64) all fit in
+	BitAsUint64 BitMode = iota
+	// BitAsBool convert to bool, non-zero is true, suit for BIT(1) flag columns
+	BitAsBool
+)
+
+// BitConverter converter of BIT values with given mode
+// database/sql doesn't expose the BIT width, register BitConverter(BitAsBool) when BIT columns are flags
+// under ValueAsPointer, BitAsUint64 keeps raw *string like before BIT converted, BitAsBool is *bool
+func BitConverter(mode BitMode) Converter {
+	converter := Converter{
+		Name:        "handle BIT",
+		ScanType:    reflect.TypeOf(sql.RawBytes{}),
+		MySQLType:   "BIT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertBitUint64,
+	}
+	if mode == BitAsBool {
+		converter.ValueType = reflect.TypeOf(false)
+		converter.ReplaceFunc = convertBitBool
+		return pointerInLegacy(converter)
+	}
+	return rawInLegacy(converter)
+}
+
+// convertBitUint64 convert BIT into uint64
+func convertBitUint64(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := parseBit(*in)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// convertBitBool convert BIT into bool, true when any bit is set
+func convertBitBool(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := parseBit(*in)
+	if err != nil {
+		return nil, err
+	}
+	return v != 0, nil
+}
+
+// parseBit decode BIT value, which mysql sends as big endian bytes
+func parseBit(s string) (uint64, error) {
+	if len(s) > 8 {
+		return 0, fmt.Errorf("bit value of %d bytes overflow uint64", len(s))
+	}
+	var v uint64
+	for i := 0; i < len(s); i++ {
+		v = v<<8 | uint64(s[i])
+	}
+	return v, nil
+}
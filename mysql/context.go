@@ -0,0 +1,57 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// TruncatedError scan stopped by WithMaxRows or WithMaxBytes, rows before the limit are returned along with it
+type TruncatedError struct {
+	// MaxRows limit of rows reached, 0 when not limited by rows
+	MaxRows int
+	// MaxBytes limit of bytes reached, 0 when not limited by bytes
+	MaxBytes int64
+	// Rows count of rows scanned
+	Rows int
+	// Bytes raw bytes of rows scanned, counted only when MaxBytes set
+	Bytes int64
+}
+
+func (e *TruncatedError) Error() string {
+	if e.MaxBytes > 0 {
+		return fmt.Sprintf("result truncated at %d rows, exceed max bytes %d", e.Rows, e.MaxBytes)
+	}
+	return fmt.Sprintf("result truncated at %d rows, exceed max rows %d", e.Rows, e.MaxRows)
+}
+
+func isTruncated(err error) bool {
+	var truncated *TruncatedError
+	return errors.As(err, &truncated)
+}
+
+// isPartial scan stopped by limits or done context, rows scanned before are returned along with the error
+func isPartial(err error) bool {
+	return isTruncated(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// NewScannerContext same as NewScanner, but Next stops with ctx error once ctx done
+func NewScannerContext(ctx context.Context, rows *sql.Rows, opts ...Option) (*Scanner, error) {
+	return NewScanner(rows, append(opts[:len(opts):len(opts)], withContext(ctx))...)
+}
+
+// ScanContext same as Scan, but stop between rows once ctx done, rows scanned before are returned along with ctx error
+// use rows of QueryContext to cancel the running query itself
+func ScanContext(ctx context.Context, rows *sql.Rows, opts ...Option) ([][]any, error) {
+	return Scan(rows, append(opts[:len(opts):len(opts)], withContext(ctx))...)
+}
+
+// ScanMappedContext same as ScanMapped, but stop between rows once ctx done, rows scanned before are returned along with ctx error
+func ScanMappedContext(ctx context.Context, rows *sql.Rows, opts ...Option) ([]map[string]any, error) {
+	return ScanMapped(rows, append(opts[:len(opts):len(opts)], withContext(ctx))...)
+}
@@ -0,0 +1,113 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// queryNumbers query rows [1, 'a'] ... [5, 'e']
+func queryNumbers(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, mysqltest.ResultSet{
+		Columns: t1.Columns,
+		Rows:    [][]any{{int64(1), "a"}, {int64(2), "b"}, {int64(3), "c"}, {int64(4), "d"}, {int64(5), "e"}},
+	})
+}
+
+// TestScanMaxRows
+// 5 rows scanned with max rows 3 given 3 rows and TruncatedError, with max rows 5 given all rows
+func TestScanMaxRows(t *testing.T) {
+	allValues, err := Scan(queryNumbers(t), WithMaxRows(3))
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("expect TruncatedError, got %v", err)
+	}
+	if len(allValues) != 3 || truncated.Rows != 3 || truncated.MaxRows != 3 {
+		t.Errorf("expect 3 rows, got %d, %+v", len(allValues), truncated)
+	}
+	allValues, err = Scan(queryNumbers(t), WithMaxRows(5))
+	if err != nil || len(allValues) != 5 {
+		t.Errorf("expect 5 rows, got %d %v", len(allValues), err)
+	}
+}
+
+// TestScanMaxBytes
+// each row has 2 raw bytes, max bytes 5 given 2 rows and TruncatedError
+func TestScanMaxBytes(t *testing.T) {
+	mappedRows, err := ScanMapped(queryNumbers(t), WithMaxBytes(5))
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) {
+		t.Fatalf("expect TruncatedError, got %v", err)
+	}
+	if len(mappedRows) != 2 || truncated.Bytes != 4 || truncated.MaxBytes != 5 {
+		t.Errorf("expect 2 rows of 4 bytes, got %d, %+v", len(mappedRows), truncated)
+	}
+}
+
+// TestScanContextCanceled
+// scan with canceled context given context.Canceled and no rows
+func TestScanContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	allValues, err := ScanContext(ctx, queryNumbers(t))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled, got %v", err)
+	}
+	if allValues != nil {
+		t.Errorf("expect no rows, got %v", allValues)
+	}
+}
+
+// TestScanContextCanceledBetweenRows
+// context canceled while converting row 2 of 5, ScanContext given 2 rows and context.Canceled
+// scanner canceled after 3 rows stops with context.Canceled
+func TestScanContextCanceledBetweenRows(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	registry := DefaultRegistry.Clone()
+	err := registry.Override(Converter{
+		Name:      "cancel at b",
+		MySQLType: "VARCHAR",
+		ReplaceFunc: func(in *string) (any, error) {
+			if in != nil && *in == "b" {
+				cancel()
+			}
+			return convertString(in)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	allValues, err := ScanContext(ctx, queryNumbers(t), WithRegistry(registry))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expect context.Canceled, got %v", err)
+	}
+	if len(allValues) != 2 || allValues[1][1] != "b" {
+		t.Errorf("expect 2 rows until b, got %v", allValues)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	scanner, err := NewScannerContext(ctx, queryNumbers(t))
+	if err != nil {
+		t.Fatalf("NewScannerContext() failed: %v", err)
+	}
+	var count int
+	for scanner.Next() {
+		count++
+		if count == 3 {
+			cancel()
+		}
+	}
+	if !errors.Is(scanner.Err(), context.Canceled) || count != 3 {
+		t.Errorf("expect 3 rows and context.Canceled, got %d %v", count, scanner.Err())
+	}
+}
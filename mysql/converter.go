@@ -11,225 +11,505 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// fractional seconds of DATETIME(6), TIMESTAMP(6) like 2006-01-02 15:04:05.123456
+// are accepted by time.Parse right after seconds, though layouts don't have it
 const (
 	dateFormat      = "2006-01-02"
 	dateTimeFormat1 = "2006-01-02 15:04:05"
 	dateTimeFormat2 = "2006-01-02T15:04:05Z"
 )
 
-type mysqlTypeConverter struct {
+// Converter convert the raw column value of a database type into go value
+// MySQLType is the database type name reported by sql.ColumnType.DatabaseTypeName, like BIGINT, DATETIME
+// ReplaceFunc receives nil when the column value is NULL
+type Converter struct {
 	Name        string
 	MySQLType   string
 	ScanType    reflect.Type
 	ReplaceFunc func(*string) (any, error)
+	// ValueType go type of values returned by ReplaceFunc except nil, used as field type by ScanStructs
+	// nil means unknown or mixed, field type will be any
+	ValueType reflect.Type
+	// Configure build ReplaceFunc and ValueType of a scan from its options, used instead of them when not nil
+	// built-in converters have it, so they follow WithLocation, WithJSONMode, WithValueMode and so on
+	// registries set ReplaceFunc and ValueType from it with default options, set it nil to use a replaced ReplaceFunc as is
+	Configure func(opts ConverterOptions) (func(*string) (any, error), reflect.Type)
 }
 
-// grafanaMySQLTypeConverters
+// ConverterOptions scan options a converter can follow, see Converter.Configure
+type ConverterOptions struct {
+	// ServerLocation location DATETIME, DATE and TIMESTAMP text is parsed in, see WithServerLocation
+	ServerLocation *time.Location
+	// Location output location of time values, see WithLocation
+	Location    *time.Location
+	TimestampAs TimestampMode
+	JSONMode    JSONMode
+	ZeroDate    ZeroDatePolicy
+	// BinaryEncoding json encoding of binary columns, see WithBinaryEncoding
+	BinaryEncoding BinaryEncoding
+	// ValueMode shape of values, see WithValueMode
+	ValueMode ValueMode
+}
+
+// configured converter with ReplaceFunc and ValueType built by Configure with default options
+func (c Converter) configured() Converter {
+	if c.Configure != nil {
+		c.ReplaceFunc, c.ValueType = c.Configure(newConfig().converterOptions())
+	}
+	return c
+}
+
+// resolve ReplaceFunc and ValueType of converter under given scan options
+func (c Converter) resolve(cfg *config) (func(*string) (any, error), reflect.Type) {
+	if c.Configure != nil {
+		return c.Configure(cfg.converterOptions())
+	}
+	return c.ReplaceFunc, c.ValueType
+}
+
+// valueType ValueType of converter under given scan options
+func (c Converter) valueType(cfg *config) reflect.Type {
+	_, valueType := c.resolve(cfg)
+	return valueType
+}
+
+// replaceFunc ReplaceFunc of converter under given scan options
+func (c Converter) replaceFunc(cfg *config) func(*string) (any, error) {
+	replaceFunc, _ := c.resolve(cfg)
+	return replaceFunc
+}
+
+// pointerInLegacy set Configure of converter returning pointer of its values like *int64 under ValueAsPointer
+// for types whose converter returned pointers before ValueMode introduced
+func pointerInLegacy(c Converter) Converter {
+	replaceFunc, valueType := c.ReplaceFunc, c.ValueType
+	c.Configure = func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+		if opts.ValueMode == ValueAsPointer {
+			return pointerValue(replaceFunc, valueType), reflect.PointerTo(valueType)
+		}
+		return replaceFunc, valueType
+	}
+	return c
+}
+
+// rawInLegacy set Configure of converter keeping raw *string under ValueAsPointer, nil *string for NULL
+// for types without converter before ValueMode introduced
+func rawInLegacy(c Converter) Converter {
+	replaceFunc, valueType := c.ReplaceFunc, c.ValueType
+	c.Configure = func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+		if opts.ValueMode == ValueAsPointer {
+			return convertStringPointer, reflect.TypeOf((*string)(nil))
+		}
+		return replaceFunc, valueType
+	}
+	return c
+}
+
+// pointerValue wrap replace func to return pointer of value type, like *int64 and *any for parsed JSON
+func pointerValue(replaceFunc func(*string) (any, error), valueType reflect.Type) func(*string) (any, error) {
+	return func(in *string) (any, error) {
+		v, err := replaceFunc(in)
+		if v == nil || err != nil {
+			return v, err
+		}
+		value := reflect.ValueOf(v)
+		pointerType := valueType
+		if pointerType == nil || !value.Type().AssignableTo(pointerType) {
+			pointerType = value.Type()
+		}
+		pointer := reflect.New(pointerType)
+		pointer.Elem().Set(value)
+		return pointer.Interface(), nil
+	}
+}
+
+// mysqlTypeConverters built-in converters of DefaultRegistry
 // copy from github.com/grafana/grafana/pkg/tsdb/mysql/mysql.go
 // add timestamp converter and change time related to local time
 // ref: https://dev.mysql.com/doc/refman/8.4/en/data-types.html
-var mysqlTypeConverters = []mysqlTypeConverter{
-	{
-		Name:      "handle DOUBLE",
-		ScanType:  reflect.TypeOf(sql.NullFloat64{}),
-		MySQLType: "DOUBLE",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseFloat(*in, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
-		},
-	},
-	{
-		Name:      "handle BIGINT",
-		ScanType:  reflect.TypeOf(sql.NullInt64{}),
-		MySQLType: "BIGINT",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseInt(*in, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
-		},
-	},
-	{
-		Name:      "handle DECIMAL",
-		ScanType:  reflect.TypeOf(sql.NullFloat64{}),
-		MySQLType: "DECIMAL",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseFloat(*in, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
-		},
-	},
+// binary types are appended from binaryConverters
+var mysqlTypeConverters = append([]Converter{
+	pointerInLegacy(Converter{
+		Name:        "handle DOUBLE",
+		ScanType:    reflect.TypeOf(sql.NullFloat64{}),
+		MySQLType:   "DOUBLE",
+		ValueType:   reflect.TypeOf(float64(0)),
+		ReplaceFunc: convertFloat64,
+	}),
+	pointerInLegacy(Converter{
+		Name:        "handle BIGINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "BIGINT",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	// keep precision of DECIMAL, see DecimalConverter for other modes
+	DecimalConverter(DecimalAsDecimal),
 	{
 		Name:      "handle TIMESTAMP",
 		ScanType:  reflect.TypeOf(sql.NullInt64{}),
 		MySQLType: "TIMESTAMP",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := time.Parse(dateTimeFormat1, *in)
-			if err == nil {
-				return (&v).Local().Unix(), nil
-			}
-			v, err = time.Parse(dateTimeFormat2, *in)
-			if err == nil {
-				return (&v).Local().Unix(), nil
-			}
-			return nil, err
+		Configure: func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+			valueType := reflect.TypeOf(int64(0))
+			if opts.TimestampAs == TimestampAsTime {
+				valueType = reflect.TypeOf(time.Time{})
+			}
+			return func(in *string) (any, error) {
+				if in == nil {
+					return nil, nil
+				}
+				if isZeroDate(*in) {
+					return convertZeroDate(opts, *in, opts.TimestampAs != TimestampAsTime)
+				}
+				v, err := parseDateTime(*in, opts.ServerLocation)
+				if err != nil {
+					return nil, err
+				}
+				switch opts.TimestampAs {
+				case TimestampAsTime:
+					return v.In(opts.Location), nil
+				case TimestampAsUnixMilli:
+					return v.UnixMilli(), nil
+				case TimestampAsUnixMicro:
+					return v.UnixMicro(), nil
+				default:
+					return v.Unix(), nil
+				}
+			}, zeroDateValueType(opts, valueType)
 		},
 	},
 	{
 		Name:      "handle DATETIME",
 		ScanType:  reflect.TypeOf(sql.NullTime{}),
 		MySQLType: "DATETIME",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := time.Parse(dateTimeFormat1, *in)
-			if err == nil {
-				return (&v).Local(), nil
-			}
-			v, err = time.Parse(dateTimeFormat2, *in)
-			if err == nil {
-				return (&v).Local(), nil
-			}
-
-			return nil, err
+		Configure: func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+			return func(in *string) (any, error) {
+				if in == nil {
+					return nil, nil
+				}
+				if isZeroDate(*in) {
+					return convertZeroDate(opts, *in, false)
+				}
+				v, err := parseDateTime(*in, opts.ServerLocation)
+				if err != nil {
+					return nil, err
+				}
+				return v.In(opts.Location), nil
+			}, zeroDateValueType(opts, reflect.TypeOf(time.Time{}))
 		},
 	},
 	{
 		Name:      "handle DATE",
 		ScanType:  reflect.TypeOf(sql.NullTime{}),
 		MySQLType: "DATE",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := time.Parse(dateFormat, *in)
-			if err == nil {
-				return (&v).Local(), nil
-			}
-			v, err = time.Parse(dateTimeFormat1, *in)
-			if err == nil {
-				return (&v).Local(), nil
-			}
-			v, err = time.Parse(dateTimeFormat2, *in)
-			if err == nil {
-				return (&v).Local(), nil
-			}
-			return nil, err
-		},
-	},
-	{
-		Name:      "handle YEAR",
-		ScanType:  reflect.TypeOf(sql.NullInt64{}),
-		MySQLType: "YEAR",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseInt(*in, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
+		Configure: func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+			return func(in *string) (any, error) {
+				if in == nil {
+					return nil, nil
+				}
+				if isZeroDate(*in) {
+					return convertZeroDate(opts, *in, false)
+				}
+				// DATE has no time of day or zone, the same calendar day at midnight of output location
+				v, err := time.Parse(dateFormat, *in)
+				if err != nil {
+					v, err = parseDateTime(*in, opts.ServerLocation)
+				}
+				if err != nil {
+					return nil, err
+				}
+				year, month, day := v.Date()
+				return time.Date(year, month, day, 0, 0, 0, 0, opts.Location), nil
+			}, zeroDateValueType(opts, reflect.TypeOf(time.Time{}))
 		},
 	},
-	{
-		Name:      "handle TINYINT",
-		ScanType:  reflect.TypeOf(sql.NullInt64{}),
-		MySQLType: "TINYINT",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseInt(*in, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
-		},
-	},
-	{
-		Name:      "handle SMALLINT",
-		ScanType:  reflect.TypeOf(sql.NullInt64{}),
-		MySQLType: "SMALLINT",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			v, err := strconv.ParseInt(*in, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
-		},
-	},
-	{
-		Name:      "handle INT",
-		ScanType:  reflect.TypeOf(sql.NullInt64{}),
-		MySQLType: "INT",
+	pointerInLegacy(Converter{
+		Name:        "handle YEAR",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "YEAR",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	pointerInLegacy(Converter{
+		Name:        "handle TINYINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "TINYINT",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	pointerInLegacy(Converter{
+		Name:        "handle SMALLINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "SMALLINT",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	pointerInLegacy(Converter{
+		Name:        "handle INT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "INT",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle MEDIUMINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "MEDIUMINT",
+		ValueType:   reflect.TypeOf(int64(0)),
+		ReplaceFunc: convertInt64,
+	}),
+	// go-sql-driver report unsigned integer columns as UNSIGNED BIGINT etc.
+	// value of BIGINT UNSIGNED may exceed int64, all unsigned widths are converted to uint64
+	rawInLegacy(Converter{
+		Name:        "handle UNSIGNED BIGINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "UNSIGNED BIGINT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertUnsignedInteger,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle UNSIGNED INT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "UNSIGNED INT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertUnsignedInteger,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle UNSIGNED MEDIUMINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "UNSIGNED MEDIUMINT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertUnsignedInteger,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle UNSIGNED SMALLINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "UNSIGNED SMALLINT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertUnsignedInteger,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle UNSIGNED TINYINT",
+		ScanType:    reflect.TypeOf(sql.NullInt64{}),
+		MySQLType:   "UNSIGNED TINYINT",
+		ValueType:   reflect.TypeOf(uint64(0)),
+		ReplaceFunc: convertUnsignedInteger,
+	}),
+	pointerInLegacy(Converter{
+		Name:        "handle FLOAT",
+		ScanType:    reflect.TypeOf(sql.NullFloat64{}),
+		MySQLType:   "FLOAT",
+		ValueType:   reflect.TypeOf(float64(0)),
+		ReplaceFunc: convertFloat64,
+	}),
+	BitConverter(BitAsUint64),
+	rawInLegacy(Converter{
+		Name:      "handle TIME",
+		ScanType:  reflect.TypeOf(sql.NullString{}),
+		MySQLType: "TIME",
+		ValueType: reflect.TypeOf(Duration(0)),
 		ReplaceFunc: func(in *string) (any, error) {
 			if in == nil {
 				return nil, nil
 			}
-			v, err := strconv.ParseInt(*in, 10, 64)
-			if err != nil {
-				return nil, err
-			}
-			return &v, nil
+			return ParseDuration(*in)
 		},
-	},
-	{
-		Name:      "handle FLOAT",
-		ScanType:  reflect.TypeOf(sql.NullFloat64{}),
-		MySQLType: "FLOAT",
+	}),
+	rawInLegacy(Converter{
+		Name:      "handle SET",
+		ScanType:  reflect.TypeOf(sql.NullString{}),
+		MySQLType: "SET",
+		ValueType: reflect.TypeOf([]string(nil)),
 		ReplaceFunc: func(in *string) (any, error) {
 			if in == nil {
 				return nil, nil
 			}
-			v, err := strconv.ParseFloat(*in, 64)
-			if err != nil {
-				return nil, err
+			// empty set is empty string
+			if *in == "" {
+				return []string{}, nil
 			}
-			return &v, nil
+			return strings.Split(*in, ","), nil
 		},
-	},
+	}),
+	// text family keep string as is
+	rawInLegacy(Converter{
+		Name:        "handle ENUM",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "ENUM",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle CHAR",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "CHAR",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle VARCHAR",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "VARCHAR",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle TINYTEXT",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "TINYTEXT",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle TEXT",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "TEXT",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle MEDIUMTEXT",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "MEDIUMTEXT",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
+	rawInLegacy(Converter{
+		Name:        "handle LONGTEXT",
+		ScanType:    reflect.TypeOf(sql.NullString{}),
+		MySQLType:   "LONGTEXT",
+		ValueType:   reflect.TypeOf(""),
+		ReplaceFunc: convertString,
+	}),
 	{
 		Name:      "handle JSON",
 		ScanType:  reflect.TypeOf(sql.NullString{}),
 		MySQLType: "JSON",
-		ReplaceFunc: func(in *string) (any, error) {
-			if in == nil {
-				return nil, nil
-			}
-			var j any
-			err := json.Unmarshal([]byte(*in), &j)
-			if err != nil {
-				return nil, fmt.Errorf("failed to unmarshal JSON: %v", err)
-			}
-			return &j, nil
-		},
+		Configure: configureJSON,
 	},
+}, binaryConverters()...)
+
+// parseDateTime parse DATETIME, TIMESTAMP text like 2006-01-02 15:04:05 in location of server session
+// text like 2006-01-02T15:04:05Z is utc time itself
+func parseDateTime(in string, location *time.Location) (time.Time, error) {
+	v, err := time.ParseInLocation(dateTimeFormat1, in, location)
+	if err == nil {
+		return v, nil
+	}
+	return time.Parse(dateTimeFormat2, in)
+}
+
+// isZeroDate check date part of DATE, DATETIME text has zero year, month or day, like 0000-00-00 or 2024-01-00
+func isZeroDate(in string) bool {
+	if len(in) < len(dateFormat) || in[4] != '-' || in[7] != '-' {
+		return false
+	}
+	return in[0:4] == "0000" || in[5:7] == "00" || in[8:10] == "00"
+}
+
+// convertZeroDate convert zero date following policy of scan, numeric means TIMESTAMP converted to number
+func convertZeroDate(opts ConverterOptions, in string, numeric bool) (any, error) {
+	switch opts.ZeroDate {
+	case ZeroDateAsNil:
+		return nil, nil
+	case ZeroDateAsZeroTime:
+		if numeric {
+			return int64(0), nil
+		}
+		return time.Time{}, nil
+	case ZeroDateAsString:
+		return in, nil
+	default:
+		return nil, fmt.Errorf("zero date %s is not supported, see WithZeroDate", in)
+	}
+}
+
+// zeroDateValueType value type of time converter, unknown when zero dates are kept as string
+func zeroDateValueType(opts ConverterOptions, valueType reflect.Type) reflect.Type {
+	if opts.ZeroDate == ZeroDateAsString {
+		return nil
+	}
+	return valueType
+}
+
+// convertInt64 convert signed integer of any width into int64
+func convertInt64(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseInt(*in, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// convertFloat64 convert FLOAT and DOUBLE into float64
+func convertFloat64(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(*in, 64)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// convertUnsignedInteger convert unsigned integer of any width into uint64
+func convertUnsignedInteger(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseUint(*in, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// convertString keep string value of text columns
+func convertString(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	return *in, nil
+}
+
+// convertStringPointer keep raw *string, nil *string for NULL, the shape of columns without converter before ValueMode introduced
+func convertStringPointer(in *string) (any, error) {
+	return in, nil
+}
+
+// configureJSON convert JSON into parsed value or keep its text following JSONMode, pointer of it under ValueAsPointer
+func configureJSON(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+	valueType := anyType
+	if opts.JSONMode == JSONAsString {
+		valueType = reflect.TypeOf("")
+	}
+	replaceFunc := func(in *string) (any, error) {
+		if in == nil {
+			return nil, nil
+		}
+		if opts.JSONMode == JSONAsString {
+			return *in, nil
+		}
+		var j any
+		err := json.Unmarshal([]byte(*in), &j)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		return j, nil
+	}
+	// JSON was *any before ValueMode introduced
+	if opts.ValueMode == ValueAsPointer {
+		return pointerValue(replaceFunc, valueType), reflect.PointerTo(valueType)
+	}
+	return replaceFunc, valueType
 }
 
 // goSQLTypeConverter a simplified goSQLTypeConverter
@@ -240,7 +520,10 @@ type goSQLTypeConverter struct {
 }
 
 // SimplySQLTypeConverters
-// simplified grafana mysql converters
+// simplified grafana mysql converters matched by scan type of driver, values are pointers like *int64, *time.Time and **string
+// used by the deprecated scan functions under ValueAsPointer only
+//
+// Deprecated: other scan functions convert by database type name with Registry, use DefaultRegistry instead.
 var SimplySQLTypeConverters = []goSQLTypeConverter{
 	{
 		Name:      "NullTime",
@@ -349,3 +632,17 @@ var SimplySQLTypeConverters = []goSQLTypeConverter{
 		},
 	},
 }
+
+// scanTypeReplaceFuncs converter of SimplySQLTypeConverters matched by scan type of each column, nil means keep raw value
+func scanTypeReplaceFuncs(colTypes []*sql.ColumnType) []func(*string) (any, error) {
+	funcs := make([]func(*string) (any, error), len(colTypes))
+	for i, colType := range colTypes {
+		for _, converter := range SimplySQLTypeConverters {
+			if colType.ScanType() == converter.InputType {
+				funcs[i] = converter.ReplaceFunc
+				break
+			}
+		}
+	}
+	return funcs
+}
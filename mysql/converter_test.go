@@ -0,0 +1,83 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"testing"
+)
+
+// TestUnsignedIntegerConverters
+// unsigned values beyond signed range converted without overflow
+func TestUnsignedIntegerConverters(t *testing.T) {
+	tests := []struct {
+		mysqlType string
+		in        string
+	}{
+		{"UNSIGNED BIGINT", "18446744073709551615"},
+		{"BIGINT UNSIGNED", "9223372036854775808"},
+		{"UNSIGNED INT", "4294967295"},
+		{"UNSIGNED MEDIUMINT", "16777215"},
+		{"UNSIGNED SMALLINT", "65535"},
+		{"unsigned tinyint", "255"},
+	}
+	for _, test := range tests {
+		in := test.in
+		got, err := convertWithOptions(t, test.mysqlType, &in)
+		if err != nil {
+			t.Errorf("%s convert %s failed: %v", test.mysqlType, test.in, err)
+			continue
+		}
+		if got != test.in {
+			t.Errorf("%s expect %s, got %s", test.mysqlType, test.in, got)
+		}
+	}
+	negative := "-1"
+	if _, err := convertWithOptions(t, "UNSIGNED BIGINT", &negative); err == nil {
+		t.Errorf("UNSIGNED BIGINT convert -1 expect error")
+	}
+	if got, err := convertWithOptions(t, "UNSIGNED INT", nil); err != nil || got != "null" {
+		t.Errorf("UNSIGNED INT NULL expect null, got %s %v", got, err)
+	}
+}
+
+// TestMySQLTypeConverters
+// MEDIUMINT, BIT, ENUM, SET, TIME, text and blob family converted by built-in converters
+func TestMySQLTypeConverters(t *testing.T) {
+	tests := []struct {
+		mysqlType string
+		in        string
+		expect    string
+	}{
+		{"MEDIUMINT", "-8388608", "-8388608"},
+		{"BIT", "\x01", "1"},
+		{"BIT", "\x01\x00", "256"},
+		{"ENUM", "small", "\"small\""},
+		{"SET", "a,b", "[\"a\",\"b\"]"},
+		{"SET", "", "[]"},
+		{"TIME", "-838:59:59", "\"-838:59:59\""},
+		{"TIME", "25:00:00", "\"25:00:00\""},
+		{"CHAR", "c", "\"c\""},
+		{"VARCHAR(18)", "mysql", "\"mysql\""},
+		{"LONGTEXT", "text", "\"text\""},
+		{"BLOB", "\x01\x02\x03", "\"AQID\""},
+		{"DECIMAL(10,2)", "1.50", "1.50"},
+		{"INT(11) UNSIGNED", "4294967295", "4294967295"},
+	}
+	for _, test := range tests {
+		in := test.in
+		got, err := convertWithOptions(t, test.mysqlType, &in)
+		if err != nil {
+			t.Errorf("%s convert %q failed: %v", test.mysqlType, test.in, err)
+			continue
+		}
+		if got != test.expect {
+			t.Errorf("%s convert %q expect %s, got %s", test.mysqlType, test.in, test.expect, got)
+		}
+	}
+	flag := "\x01"
+	if v, err := BitConverter(BitAsBool).ReplaceFunc(&flag); err != nil || v != true {
+		t.Errorf("BitAsBool expect true, got %v %v", v, err)
+	}
+}
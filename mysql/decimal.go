@@ -0,0 +1,159 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Decimal exact text of a mysql DECIMAL value like -1234.5600, marshal as json number without rounding
+type Decimal string
+
+// DecimalMode how DECIMAL values are converted
+type DecimalMode int
+
+const (
+	// DecimalAsDecimal convert to Decimal, exact and marshaled as json number
+	DecimalAsDecimal DecimalMode = iota
+	// DecimalAsString convert to string, exact and marshaled as json string
+	DecimalAsString
+	// DecimalAsFloat64 convert to float64, may lose precision, the behavior before Decimal introduced
+	DecimalAsFloat64
+)
+
+// ParseDecimal check s is a plain decimal number like -1234.5600
+func ParseDecimal(s string) (Decimal, error) {
+	if !isDecimal(s) {
+		return "", fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal(s), nil
+}
+
+// String exact decimal text
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// Float64 nearest float64 of decimal, may lose precision
+func (d Decimal) Float64() (float64, error) {
+	return strconv.ParseFloat(string(d), 64)
+}
+
+// MarshalJSON marshal decimal as json number keeping all digits
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !isDecimal(string(d)) {
+		return nil, fmt.Errorf("invalid decimal %q", string(d))
+	}
+	return []byte(d), nil
+}
+
+// UnmarshalJSON accept json number or string of a decimal, null is a no-op like encoding/json does
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// DecimalConverter converter of DECIMAL values with given mode, override DefaultRegistry or own registry with it
+// values are *float64 under ValueAsPointer with DecimalAsDecimal as before, pointer of string or float64 with other modes
+//
+//	registry.Override(DecimalConverter(DecimalAsString))
+func DecimalConverter(mode DecimalMode) Converter {
+	converter := Converter{
+		Name:      "handle DECIMAL",
+		ScanType:  reflect.TypeOf(sql.NullString{}),
+		MySQLType: "DECIMAL",
+		ValueType: reflect.TypeOf(Decimal("")),
+	}
+	switch mode {
+	case DecimalAsString:
+		converter.ValueType = reflect.TypeOf("")
+		converter.ReplaceFunc = func(in *string) (any, error) {
+			if in == nil {
+				return nil, nil
+			}
+			if !isDecimal(*in) {
+				return nil, fmt.Errorf("invalid decimal %q", *in)
+			}
+			return *in, nil
+		}
+	case DecimalAsFloat64:
+		converter.ScanType = reflect.TypeOf(sql.NullFloat64{})
+		converter.ValueType = reflect.TypeOf(float64(0))
+		converter.ReplaceFunc = convertDecimalFloat64
+	default:
+		converter.ReplaceFunc = convertDecimal
+		// DECIMAL was *float64 before Decimal and ValueMode introduced
+		converter.Configure = func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+			if opts.ValueMode == ValueAsPointer {
+				return pointerValue(convertDecimalFloat64, reflect.TypeOf(float64(0))), reflect.TypeOf((*float64)(nil))
+			}
+			return convertDecimal, reflect.TypeOf(Decimal(""))
+		}
+		return converter
+	}
+	return pointerInLegacy(converter)
+}
+
+// convertDecimal convert DECIMAL into exact Decimal
+func convertDecimal(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	return ParseDecimal(*in)
+}
+
+// convertDecimalFloat64 convert DECIMAL into float64, may lose precision
+func convertDecimalFloat64(in *string) (any, error) {
+	if in == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(*in, 64)
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// isDecimal check s matches -?digits[.digits], which is also a valid json number
+func isDecimal(s string) bool {
+	if len(s) > 0 && s[0] == '-' {
+		s = s[1:]
+	}
+	var digits, fractionDigits int
+	var dot bool
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] >= '0' && s[i] <= '9':
+			if dot {
+				fractionDigits++
+			} else {
+				digits++
+			}
+		case s[i] == '.' && !dot:
+			dot = true
+		default:
+			return false
+		}
+	}
+	if digits == 0 || (dot && fractionDigits == 0) {
+		return false
+	}
+	// json number disallow leading zeros like 007
+	return !(digits > 1 && s[0] == '0')
+}
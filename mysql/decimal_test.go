@@ -0,0 +1,66 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const preciseDecimal = "12345678901234567890.123456789012345678"
+
+// TestDecimalConverter
+// DECIMAL value 12345678901234567890.123456789012345678 marshaled by each mode
+func TestDecimalConverter(t *testing.T) {
+	tests := []struct {
+		mode   DecimalMode
+		expect string
+	}{
+		{DecimalAsDecimal, preciseDecimal},
+		{DecimalAsString, "\"" + preciseDecimal + "\""},
+		{DecimalAsFloat64, "12345678901234567000"},
+	}
+	for _, test := range tests {
+		in := preciseDecimal
+		v, err := DecimalConverter(test.mode).ReplaceFunc(&in)
+		if err != nil {
+			t.Errorf("mode %d convert failed: %v", test.mode, err)
+			continue
+		}
+		bytes, err := json.Marshal(v)
+		if err != nil {
+			t.Errorf("mode %d json.Marshal() failed: %v", test.mode, err)
+		}
+		if string(bytes) != test.expect {
+			t.Errorf("mode %d expect %s, got %s", test.mode, test.expect, bytes)
+		}
+	}
+	if v, err := DecimalConverter(DecimalAsDecimal).ReplaceFunc(nil); v != nil || err != nil {
+		t.Errorf("NULL expect nil, got %v %v", v, err)
+	}
+}
+
+// TestParseDecimal
+// valid mysql decimal text pass, others failed
+func TestParseDecimal(t *testing.T) {
+	for _, s := range []string{"0", "-0.50", "10", "123.4500"} {
+		if _, err := ParseDecimal(s); err != nil {
+			t.Errorf("ParseDecimal(%q) failed: %v", s, err)
+		}
+	}
+	for _, s := range []string{"", "-", "1.", ".5", "01", "1e5", "abc", "1.2.3"} {
+		if _, err := ParseDecimal(s); err == nil {
+			t.Errorf("ParseDecimal(%q) expect error", s)
+		}
+	}
+	var d Decimal
+	if err := json.Unmarshal([]byte("\"-1.25\""), &d); err != nil || d != "-1.25" {
+		t.Errorf("json.Unmarshal() got %v %v", d, err)
+	}
+	// null keeps value like encoding/json does for other types
+	if err := json.Unmarshal([]byte("null"), &d); err != nil || d != "-1.25" {
+		t.Errorf("json.Unmarshal() of null got %v %v", d, err)
+	}
+}
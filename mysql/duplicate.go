@@ -0,0 +1,98 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// DuplicateStrategy how duplicate column names like a.id, b.id of JOIN are keyed in mapped rows
+type DuplicateStrategy int
+
+const (
+	// DuplicateAsError fail the mapped scan with duplicate column name error
+	DuplicateAsError DuplicateStrategy = iota
+	// DuplicateWithSuffix suffix later duplicates with their occurrence, id, id_2, id_3
+	DuplicateWithSuffix
+	// DuplicateKeepLast keep value of the last duplicate column, at the position of the first one
+	DuplicateKeepLast
+	// DuplicateAsArray group values of duplicate columns into []any in select order
+	DuplicateAsArray
+)
+
+// columnKeys keys of mapped rows resolved from column names
+type columnKeys struct {
+	// names unique keys in select order of their first column
+	names []string
+	// keyOf index of key in names of each column
+	keyOf []int
+	// grouped keys whose values are grouped into []any
+	grouped []bool
+}
+
+// resolveColumnKeys resolve keys of columns with strategy, error only with DuplicateAsError
+func resolveColumnKeys(colNames []string, strategy DuplicateStrategy) (*columnKeys, error) {
+	keys := &columnKeys{keyOf: make([]int, len(colNames))}
+	index := make(map[string]int, len(colNames))
+	occurrences := make(map[string]int, len(colNames))
+	for i, colName := range colNames {
+		occurrences[colName]++
+		k, duplicate := index[colName]
+		if !duplicate {
+			index[colName] = len(keys.names)
+			keys.keyOf[i] = len(keys.names)
+			keys.names = append(keys.names, colName)
+			keys.grouped = append(keys.grouped, false)
+			continue
+		}
+		switch strategy {
+		case DuplicateWithSuffix:
+			// skip suffix taken by other column like id_2
+			n := occurrences[colName]
+			name := colName + "_" + strconv.Itoa(n)
+			for keyTaken(index, colNames, name) {
+				n++
+				name = colName + "_" + strconv.Itoa(n)
+			}
+			index[name] = len(keys.names)
+			keys.keyOf[i] = len(keys.names)
+			keys.names = append(keys.names, name)
+			keys.grouped = append(keys.grouped, false)
+		case DuplicateKeepLast:
+			keys.keyOf[i] = k
+		case DuplicateAsArray:
+			keys.keyOf[i] = k
+			keys.grouped[k] = true
+		default:
+			return nil, fmt.Errorf("%w %s", ErrDuplicateColumn, colName)
+		}
+	}
+	return keys, nil
+}
+
+func keyTaken(index map[string]int, colNames []string, name string) bool {
+	_, ok := index[name]
+	return ok || slices.Contains(colNames, name)
+}
+
+// keyedValues values of row in order of keys, duplicates merged following strategy
+func (keys *columnKeys) keyedValues(row []any) []any {
+	if len(keys.names) == len(row) {
+		return row
+	}
+	values := make([]any, len(keys.names))
+	for i, v := range row {
+		k := keys.keyOf[i]
+		if keys.grouped[k] {
+			group, _ := values[k].([]any)
+			values[k] = append(group, v)
+			continue
+		}
+		values[k] = v
+	}
+	return values
+}
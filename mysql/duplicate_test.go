@@ -0,0 +1,69 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestDuplicateColumns
+// sql: SELECT a.id, a.name, b.id, b.id_2 ... row: [1, 'a', 2, 'x']
+// expect result keyed by each strategy
+func TestDuplicateColumns(t *testing.T) {
+	joined := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "name", DatabaseType: "VARCHAR"}, {Name: "id", DatabaseType: "BIGINT"}, {Name: "id_2", DatabaseType: "VARCHAR"}},
+		Rows:    [][]any{{"1", "a", "2", "x"}},
+	}
+	tests := []struct {
+		strategy DuplicateStrategy
+		expect   string
+	}{
+		{DuplicateWithSuffix, "[{\"id\":1,\"name\":\"a\",\"id_3\":2,\"id_2\":\"x\"}]"},
+		{DuplicateKeepLast, "[{\"id\":2,\"name\":\"a\",\"id_2\":\"x\"}]"},
+		{DuplicateAsArray, "[{\"id\":[1,2],\"name\":\"a\",\"id_2\":\"x\"}]"},
+	}
+	for _, test := range tests {
+		orderedRows, err := ScanOrderedRows(queryFake(t, joined), WithDuplicateColumns(test.strategy))
+		if err != nil {
+			t.Errorf("strategy %d ScanOrderedRows() failed: %v", test.strategy, err)
+			continue
+		}
+		bytes, err := json.Marshal(orderedRows)
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		}
+		if string(bytes) != test.expect {
+			t.Errorf("strategy %d expect %s, got %s", test.strategy, test.expect, bytes)
+		}
+	}
+
+	if _, err := ScanMapped(queryFake(t, joined)); err == nil {
+		t.Errorf("ScanMapped() with default strategy expect duplicate column error")
+	}
+}
+
+// TestDuplicateColumnsMapped
+// sql: SELECT a.id, b.id ... row: [1, 2], grouped into array by ScanMapped
+func TestDuplicateColumnsMapped(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "id", DatabaseType: "BIGINT"}},
+		Rows:    [][]any{{"1", nil}},
+	})
+	mappedRows, err := ScanMapped(rows, WithDuplicateColumns(DuplicateAsArray))
+	if err != nil {
+		t.Fatalf("ScanMapped() failed: %v", err)
+	}
+	bytes, err := json.Marshal(mappedRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const mappedJson = "[{\"id\":[1,null]}]"
+	if string(bytes) != mappedJson {
+		t.Errorf("expect %s, got %s", mappedJson, bytes)
+	}
+}
@@ -0,0 +1,116 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration value of mysql TIME column, which is an elapsed time rather than time of day
+// range from -838:59:59.000000 to 838:59:59.000000, so may be negative or longer than 24 hours
+type Duration time.Duration
+
+// ParseDuration parse mysql TIME text like -838:59:59, 12:00:00.123456 or 1 12:00:00
+func ParseDuration(s string) (Duration, error) {
+	text := strings.TrimSpace(s)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+	// optional day part like "1 12:00:00"
+	var days int64
+	if dayText, rest, ok := strings.Cut(text, " "); ok {
+		var err error
+		if days, err = strconv.ParseInt(dayText, 10, 64); err != nil || !isDigits(dayText) {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		text = rest
+	}
+	text, fraction, hasFraction := strings.Cut(text, ".")
+	parts := strings.Split(text, ":")
+	if len(parts) != 3 || len(fraction) > 9 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	var fields [3]int64
+	for i, part := range parts {
+		v, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || !isDigits(part) || (i > 0 && v > 59) {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		fields[i] = v
+	}
+	d := time.Duration(days*24+fields[0])*time.Hour + time.Duration(fields[1])*time.Minute + time.Duration(fields[2])*time.Second
+	if hasFraction {
+		// strconv.ParseInt accepts sign, which fraction can't have
+		if !isDigits(fraction) {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		nanos, err := strconv.ParseInt(fraction+strings.Repeat("0", 9-len(fraction)), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		d += time.Duration(nanos)
+	}
+	if negative {
+		d = -d
+	}
+	return Duration(d), nil
+}
+
+// isDigits check s is ascii digits only
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// Duration convert to time.Duration
+func (d Duration) Duration() time.Duration {
+	return time.Duration(d)
+}
+
+// String format as mysql TIME text like -838:59:59 or 12:00:00.123456, fraction is trimmed to microsecond
+func (d Duration) String() string {
+	v := time.Duration(d)
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	hours := v / time.Hour
+	minutes := (v % time.Hour) / time.Minute
+	seconds := (v % time.Minute) / time.Second
+	micros := (v % time.Second) / time.Microsecond
+	if micros == 0 {
+		return fmt.Sprintf("%s%02d:%02d:%02d", sign, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign, hours, minutes, seconds, micros)
+}
+
+// MarshalJSON marshal as mysql TIME text string
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accept mysql TIME text string, null is a no-op like encoding/json does
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("invalid time %s", data)
+	}
+	v, err := ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
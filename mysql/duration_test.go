@@ -0,0 +1,54 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestParseDuration
+// mysql TIME text parsed into Duration and formatted back
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in     string
+		expect time.Duration
+		text   string
+	}{
+		{"00:00:00", 0, "00:00:00"},
+		{"12:30:05", 12*time.Hour + 30*time.Minute + 5*time.Second, "12:30:05"},
+		{"-838:59:59", -(838*time.Hour + 59*time.Minute + 59*time.Second), "-838:59:59"},
+		{"1 02:00:00", 26 * time.Hour, "26:00:00"},
+		{"-00:00:01.5", -1500 * time.Millisecond, "-00:00:01.500000"},
+		{"10:00:00.000123", 10*time.Hour + 123*time.Microsecond, "10:00:00.000123"},
+	}
+	for _, test := range tests {
+		d, err := ParseDuration(test.in)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) failed: %v", test.in, err)
+			continue
+		}
+		if d.Duration() != test.expect {
+			t.Errorf("ParseDuration(%q) expect %v, got %v", test.in, test.expect, d.Duration())
+		}
+		if d.String() != test.text {
+			t.Errorf("Duration(%q).String() expect %s, got %s", test.in, test.text, d.String())
+		}
+	}
+	for _, in := range []string{"", "12:00", "12:60:00", "a:b:c", "12:00:00.1234567890", "12:00:00.+1", "12:00:00.-1", "12:+1:00", "+1:00:00", "12:00:00."} {
+		if _, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) expect error", in)
+		}
+	}
+	var d Duration
+	if err := json.Unmarshal([]byte("\"-12:30:05\""), &d); err != nil || d.String() != "-12:30:05" {
+		t.Errorf("json.Unmarshal() got %v %v", d, err)
+	}
+	// null keeps value like encoding/json does for other types
+	if err := json.Unmarshal([]byte("null"), &d); err != nil || d.String() != "-12:30:05" {
+		t.Errorf("json.Unmarshal() of null got %v %v", d, err)
+	}
+}
@@ -0,0 +1,182 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var anyType = reflect.TypeOf((*any)(nil)).Elem()
+
+// ScanStructs scan rows into values of a struct type generated from columns by reflect.StructOf
+// field names are exported forms of column names, json tags are the original column names,
+// column names encoding/json can't keep as tag name, like user's name, fail the scan
+// field types come from ValueType of converters, nullable types are made pointers, unknown types are any
+// return []struct{...} as any, which marshals faster and uses less memory than []map[string]any
+// duplicate column names are keyed following WithDuplicateColumns, error by default
+// fields are not nested, error with WithNestedKeys
+// with WithLenient(FallbackAsRaw, ...) fields of non-string types are any, to hold raw text of failed cells
+func ScanStructs(rows *sql.Rows, opts ...Option) (any, error) {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = scanner.flatErr(); err != nil {
+		return nil, err
+	}
+	structType, err := scanner.structType()
+	if err != nil {
+		return nil, err
+	}
+	allRows := reflect.Zero(reflect.SliceOf(structType))
+	for scanner.Next() {
+		structRow := reflect.New(structType).Elem()
+		for i, v := range scanner.keys.keyedValues(scanner.row) {
+			if err = setField(structRow.Field(i), v); err != nil {
+				return nil, fmt.Errorf("set field of column %s failed, %w", scanner.keys.names[i], err)
+			}
+		}
+		allRows = reflect.Append(allRows, structRow)
+	}
+	if err = scanner.Err(); err != nil {
+		if isPartial(err) {
+			return allRows.Interface(), err
+		}
+		return nil, err
+	}
+	return allRows.Interface(), nil
+}
+
+// structType generate struct type of keys of mapped rows, error when a key can't be json tag name
+func (s *Scanner) structType() (reflect.Type, error) {
+	var colMySQLTypes []string
+	for _, colType := range s.colTypes {
+		colMySQLTypes = append(colMySQLTypes, colType.DatabaseTypeName())
+	}
+	colValueTypes := s.cfg.registryOrDefault().valueTypes(s.cfg, colMySQLTypes)
+	// value type of key is the one of its last column, like DuplicateKeepLast
+	keyValueTypes := make([]reflect.Type, len(s.keys.names))
+	for i, valueType := range colValueTypes {
+		keyValueTypes[s.keys.keyOf[i]] = valueType
+	}
+	fields := make([]reflect.StructField, len(s.keys.names))
+	fieldNames := make(map[string]bool, len(s.keys.names))
+	for i, key := range s.keys.names {
+		fieldType := nullableType(keyValueTypes[i])
+		// raw text of failed cells under WithLenient(FallbackAsRaw) fits string fields only
+		if s.cfg.lenient && s.cfg.fallback == FallbackAsRaw && !isStringType(fieldType) {
+			fieldType = anyType
+		}
+		if s.keys.grouped[i] {
+			fieldType = reflect.TypeOf([]any(nil))
+		}
+		fieldName := exportedFieldName(key, i)
+		for n := 2; fieldNames[fieldName]; n++ {
+			fieldName = exportedFieldName(key, i) + strconv.Itoa(n)
+		}
+		fieldNames[fieldName] = true
+		fields[i] = reflect.StructField{Name: fieldName, Type: fieldType}
+		// tag "-" drops the field, "-," names it "-"
+		switch {
+		case key == "-":
+			fields[i].Tag = `json:"-,"`
+		case isValidJSONTag(key):
+			fields[i].Tag = reflect.StructTag(`json:"` + key + `"`)
+		default:
+			return nil, fmt.Errorf("column %q can't be json key of struct field, alias it in sql", key)
+		}
+	}
+	return reflect.StructOf(fields), nil
+}
+
+// isValidJSONTag whether name is kept by encoding/json as tag name, which ignores names of other characters
+// same rules as encoding/json: letters, digits, spaces and punctuation except backslash, quotes and comma
+func isValidJSONTag(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", r):
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			return false
+		}
+	}
+	return true
+}
+
+// isStringType whether type is string or pointer to string
+func isStringType(valueType reflect.Type) bool {
+	if valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+	return valueType == reflect.TypeOf("")
+}
+
+// nullableType type able to hold nil of NULL, pointer to valueType if not
+func nullableType(valueType reflect.Type) reflect.Type {
+	if valueType == nil {
+		return anyType
+	}
+	switch valueType.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		return valueType
+	default:
+		return reflect.PointerTo(valueType)
+	}
+}
+
+// setField set converted value into field, nil keeps zero value
+func setField(field reflect.Value, v any) error {
+	if v == nil {
+		return nil
+	}
+	value := reflect.ValueOf(v)
+	switch {
+	case value.Type().AssignableTo(field.Type()):
+		field.Set(value)
+	case field.Kind() == reflect.Pointer && value.Type().AssignableTo(field.Type().Elem()):
+		ptr := reflect.New(field.Type().Elem())
+		ptr.Elem().Set(value)
+		field.Set(ptr)
+	case value.Kind() == reflect.Pointer && !value.IsNil() && value.Elem().Type().AssignableTo(field.Type()):
+		field.Set(value.Elem())
+	default:
+		return fmt.Errorf("value of type %s is not assignable to %s", value.Type(), field.Type())
+	}
+	return nil
+}
+
+// exportedFieldName exported go identifier of column name, user_name becomes UserName, 1st becomes X1st
+func exportedFieldName(colName string, index int) string {
+	var builder strings.Builder
+	upper := true
+	for _, r := range colName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		builder.WriteRune(r)
+	}
+	name := builder.String()
+	if name == "" {
+		return "Col" + strconv.Itoa(index+1)
+	}
+	first := []rune(name)[0]
+	// identifier starting with digit or non latin upper case letter is not exported
+	if !unicode.IsUpper(first) {
+		name = "X" + name
+	}
+	return name
+}
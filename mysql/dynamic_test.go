@@ -0,0 +1,138 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestScanStructs
+// sql rows: [1, 'mysql', 9.50, '2024-01-01 10:00:00', '{"a": 1}', 'x', 'dash'], [2, NULL, NULL, NULL, NULL, NULL, NULL]
+// expect result: struct fields with go types from converters, json tags of column names
+func TestScanStructs(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "user_name", DatabaseType: "VARCHAR"},
+			{Name: "price", DatabaseType: "DECIMAL"},
+			{Name: "created at", DatabaseType: "DATETIME"},
+			{Name: "content", DatabaseType: "JSON"},
+			{Name: "1st", DatabaseType: "GEOMETRY"},
+			{Name: "-", DatabaseType: "VARCHAR"},
+		},
+		Rows: [][]any{
+			{"1", "mysql", "9.50", "2024-01-01 10:00:00", "{\"a\": 1}", "x", "dash"},
+			{"2", nil, nil, nil, nil, nil, nil},
+		},
+	})
+	structRows, err := ScanStructs(rows, WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("ScanStructs() failed: %v", err)
+	}
+	bytes, err := json.Marshal(structRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const structJson = "[{\"id\":1,\"user_name\":\"mysql\",\"price\":9.50,\"created at\":\"2024-01-01T10:00:00Z\",\"content\":{\"a\":1},\"1st\":\"x\",\"-\":\"dash\"}," +
+		"{\"id\":2,\"user_name\":null,\"price\":null,\"created at\":null,\"content\":null,\"1st\":null,\"-\":null}]"
+	if string(bytes) != structJson {
+		t.Errorf("expect %s, got %s", structJson, bytes)
+	}
+	structType := reflect.TypeOf(structRows).Elem()
+	expectFields := []struct {
+		name      string
+		fieldType reflect.Type
+	}{
+		{"Id", reflect.TypeOf((*int64)(nil))},
+		{"UserName", reflect.TypeOf((*string)(nil))},
+		{"Price", reflect.TypeOf((*Decimal)(nil))},
+		{"CreatedAt", reflect.TypeOf((*time.Time)(nil))},
+		{"Content", anyType},
+		{"X1st", reflect.TypeOf((*string)(nil))},
+		{"Col7", reflect.TypeOf((*string)(nil))},
+	}
+	for i, expect := range expectFields {
+		field := structType.Field(i)
+		if field.Name != expect.name || field.Type != expect.fieldType {
+			t.Errorf("field %d expect %s %s, got %s %s", i, expect.name, expect.fieldType, field.Name, field.Type)
+		}
+	}
+}
+
+// TestScanStructsInvalidTag
+// sql: SELECT a AS `user's name`, b AS `a\`, c AS `x y` ... row: ['a', 'b', 'c']
+// expect result: error as encoding/json would ignore the first two names as tag and key them user and A
+func TestScanStructsInvalidTag(t *testing.T) {
+	for _, name := range []string{"user's name", "a\\", "a,b", "say \"hi\"", ""} {
+		rows := queryFake(t, mysqltest.ResultSet{
+			Columns: []mysqltest.Column{{Name: name, DatabaseType: "VARCHAR"}, {Name: "x y", DatabaseType: "VARCHAR"}},
+			Rows:    [][]any{{"a", "c"}},
+		})
+		if _, err := ScanStructs(rows); err == nil {
+			t.Errorf("ScanStructs() of column %q expect error", name)
+		}
+	}
+	if !isValidJSONTag("x y") || !isValidJSONTag("price(usd)") || isValidJSONTag("user's") {
+		t.Errorf("isValidJSONTag() doesn't follow encoding/json")
+	}
+}
+
+// TestExportedFieldName
+// column names converted into exported go identifiers
+func TestExportedFieldName(t *testing.T) {
+	tests := map[string]string{
+		"id":         "Id",
+		"user_name":  "UserName",
+		"user.email": "UserEmail",
+		"COUNT(*)":   "COUNT",
+		"2nd":        "X2nd",
+		"名字":         "X名字",
+		"***":        "Col1",
+	}
+	for colName, expect := range tests {
+		if got := exportedFieldName(colName, 0); got != expect {
+			t.Errorf("exportedFieldName(%q) expect %s, got %s", colName, expect, got)
+		}
+	}
+}
+
+// TestScanStructsLenient
+// sql rows: [1, 'x'], [2, 20] of columns id BIGINT, amount INT
+// expect result: with raw fallback, amount field is any holding 'x' then 20, with nil fallback it stays *int64
+func TestScanStructsLenient(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "amount", DatabaseType: "INT"}},
+		Rows:    [][]any{{"1", "x"}, {"2", "20"}},
+	}
+	report := &ConversionReport{}
+	structRows, err := ScanStructs(queryFake(t, resultSet), WithLenient(FallbackAsRaw, report))
+	if err != nil {
+		t.Fatalf("ScanStructs() failed: %v", err)
+	}
+	bytes, err := json.Marshal(structRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const structJson = "[{\"id\":1,\"amount\":\"x\"},{\"id\":2,\"amount\":20}]"
+	if string(bytes) != structJson {
+		t.Errorf("expect %s, got %s", structJson, bytes)
+	}
+	if report.Failed != 1 {
+		t.Errorf("expect 1 failure, got %d", report.Failed)
+	}
+
+	structRows, err = ScanStructs(queryFake(t, resultSet), WithLenient(FallbackAsNil, nil))
+	if err != nil {
+		t.Fatalf("ScanStructs() failed: %v", err)
+	}
+	if fieldType := reflect.TypeOf(structRows).Elem().Field(1).Type; fieldType != reflect.TypeOf((*int64)(nil)) {
+		t.Errorf("expect amount *int64 with nil fallback, got %s", fieldType)
+	}
+}
@@ -0,0 +1,40 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateColumn columns of the same name can't be keys of mapped rows, see WithDuplicateColumns
+var ErrDuplicateColumn = errors.New("duplicate column name")
+
+// ConversionError converter failed on a cell, check with errors.As
+//
+//	var convErr *ConversionError
+//	if errors.As(err, &convErr) {
+//		log.Printf("row %d column %s: %v", convErr.Row, convErr.Column, convErr.Err)
+//	}
+type ConversionError struct {
+	// Row index of row in scan, from 0
+	Row int
+	// Column name of column
+	Column string
+	// DatabaseType database type name of column, like DATETIME
+	DatabaseType string
+	// Raw text value, empty for NULL
+	Raw string
+	// Err error returned by converter
+	Err error
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("convert value failed, row %d column %s of type %s value %q, %v", e.Row, e.Column, e.DatabaseType, e.Raw, e.Err)
+}
+
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
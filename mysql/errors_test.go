@@ -0,0 +1,54 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestErrDuplicateColumn
+// sql rows: [1, 2] of columns id, id
+// expect result: errors.Is ErrDuplicateColumn, also through result set wrapping
+func TestErrDuplicateColumn(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "id", DatabaseType: "BIGINT"}},
+		Rows:    [][]any{{"1", "2"}},
+	}
+	if _, err := ScanAnonymousMappedRows(queryFake(t, resultSet)); !errors.Is(err, ErrDuplicateColumn) {
+		t.Errorf("expect ErrDuplicateColumn, got %v", err)
+	}
+	if _, err := ScanMappedResultSets(queryFake(t, resultSet)); !errors.Is(err, ErrDuplicateColumn) {
+		t.Errorf("expect ErrDuplicateColumn of result set, got %v", err)
+	}
+}
+
+// TestConversionError
+// sql rows: [1, 'x'], [2, 'y'] of columns id BIGINT, amount INT
+// expect result: ConversionError of row 0 column amount with raw value x, unwrapping to strconv.ErrSyntax
+func TestConversionError(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "amount", DatabaseType: "INT"}},
+		Rows:    [][]any{{"1", "x"}, {"2", "y"}},
+	})
+	_, err := ScanAnonymousRows(rows)
+	var convErr *ConversionError
+	if !errors.As(err, &convErr) {
+		t.Fatalf("expect ConversionError, got %v", err)
+	}
+	if convErr.Row != 0 || convErr.Column != "amount" || convErr.DatabaseType != "INT" || convErr.Raw != "x" {
+		t.Errorf("unexpected ConversionError %+v", convErr)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expect ConversionError unwrap to strconv.ErrSyntax, got %v", convErr.Err)
+	}
+	if !strings.HasPrefix(err.Error(), "convert value failed, row 0 column amount of type INT value \"x\"") {
+		t.Errorf("unexpected message %s", err)
+	}
+}
@@ -0,0 +1,31 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// newFakeDB open db whose every query returns given result sets, see mysqltest
+func newFakeDB(t *testing.T, resultSets ...mysqltest.ResultSet) *sql.DB {
+	t.Helper()
+	db := mysqltest.Open(resultSets...)
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// queryFake query rows of given result sets, closed on cleanup
+func queryFake(t *testing.T, resultSets ...mysqltest.ResultSet) *sql.Rows {
+	t.Helper()
+	rows, err := newFakeDB(t, resultSets...).Query("SELECT ...")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	t.Cleanup(func() { _ = rows.Close() })
+	return rows
+}
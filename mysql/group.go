@@ -0,0 +1,143 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// GroupMapped scan mapped rows grouped by values of given columns, nested one map level per column
+// keys are converted values with pointers dereferenced, BIGINT keys are int64, NULL key is nil
+// values of the last level are mapped rows of the group, in scan order
+// return format likes: {tenant1: {user1: [{'tenant_id': tenant1, 'user_id': user1,...}...]...}...}
+func GroupMapped(rows *sql.Rows, columns []string, opts ...Option) (map[any]any, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("no column to group by")
+	}
+	groups := make(map[any]any)
+	err := groupMappedRows(rows, columns, opts, func(keys []any, mappedRow map[string]any) error {
+		level := groups
+		for _, key := range keys[:len(keys)-1] {
+			subLevel, ok := level[key].(map[any]any)
+			if !ok {
+				subLevel = make(map[any]any)
+				level[key] = subLevel
+			}
+			level = subLevel
+		}
+		lastKey := keys[len(keys)-1]
+		groupRows, _ := level[lastKey].([]map[string]any)
+		level[lastKey] = append(groupRows, mappedRow)
+		return nil
+	})
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return groups, err
+}
+
+// GroupMappedByString same as GroupMapped with single column, but keys are formatted as string to marshal into json
+// time is formatted as RFC3339, NULL key is "null" or the one of WithNullGroupKey
+// fails instead of merging groups when a value is formatted as the NULL key, like VARCHAR 'null'
+// return format likes: {'tenant1': [{'tenant_id': tenant1,...}...]...}
+func GroupMappedByString(rows *sql.Rows, column string, opts ...Option) (map[string][]map[string]any, error) {
+	nullKey := newConfig(opts...).nullGroupKey
+	groups := make(map[string][]map[string]any)
+	var nullSeen, valueSeen bool
+	err := groupMappedRows(rows, []string{column}, opts, func(keys []any, mappedRow map[string]any) error {
+		key := nullKey
+		if keys[0] == nil {
+			nullSeen = true
+		} else if key = formatGroupKey(keys[0]); key == nullKey {
+			valueSeen = true
+		}
+		if nullSeen && valueSeen {
+			return fmt.Errorf("group key %q of NULL collides with value of column %s, see WithNullGroupKey", nullKey, column)
+		}
+		groups[key] = append(groups[key], mappedRow)
+		return nil
+	})
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return groups, err
+}
+
+// groupMappedRows call add with group keys of each mapped row
+func groupMappedRows(rows *sql.Rows, columns []string, opts []Option, add func(keys []any, mappedRow map[string]any) error) error {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return err
+	}
+	if err = scanner.mappedErr(); err != nil {
+		return err
+	}
+	keyIndexes := make([]int, len(columns))
+	for i, column := range columns {
+		keyIndexes[i] = -1
+		for j, name := range scanner.keys.names {
+			if name == column {
+				keyIndexes[i] = j
+				break
+			}
+		}
+		if keyIndexes[i] < 0 {
+			return fmt.Errorf("group column %s not found", column)
+		}
+	}
+	for scanner.Next() {
+		mappedRow, err := scanner.MappedRow()
+		if err != nil {
+			return err
+		}
+		values := scanner.keys.keyedValues(scanner.Row())
+		keys := make([]any, len(keyIndexes))
+		for i, keyIndex := range keyIndexes {
+			if keys[i], err = groupKey(values[keyIndex]); err != nil {
+				return fmt.Errorf("group by column %s failed, %w", columns[i], err)
+			}
+		}
+		if err = add(keys, mappedRow); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// groupKey dereference converted value like *int64 of ValueAsPointer into map key
+func groupKey(v any) (any, error) {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, nil
+		}
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil, nil
+	}
+	if !value.Comparable() {
+		return nil, fmt.Errorf("value of type %s can't be group key", value.Type())
+	}
+	return value.Interface(), nil
+}
+
+// formatGroupKey format non-NULL group key as string
+func formatGroupKey(key any) string {
+	switch k := key.(type) {
+	case string:
+		return k
+	case time.Time:
+		return k.Format(time.RFC3339Nano)
+	case fmt.Stringer:
+		return k.String()
+	default:
+		return fmt.Sprint(k)
+	}
+}
@@ -0,0 +1,114 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+func queryTenantUsers(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "tenant_id", DatabaseType: "BIGINT"},
+			{Name: "role", DatabaseType: "VARCHAR"},
+			{Name: "user", DatabaseType: "VARCHAR"},
+			{Name: "tags", DatabaseType: "JSON"},
+		},
+		Rows: [][]any{
+			{"1", "admin", "john", "[]"},
+			{"2", "admin", "jane", "[]"},
+			{"1", "guest", "jack", "[]"},
+			{"1", "admin", "jill", "[]"},
+			{nil, nil, "nobody", "[]"},
+		},
+	})
+}
+
+// TestGroupMapped
+// sql rows: [1, 'admin', 'john'], [2, 'admin', 'jane'], [1, 'guest', 'jack'], [1, 'admin', 'jill'], [NULL, NULL, 'nobody']
+// expect result: {1: {'admin': [john, jill], 'guest': [jack]}, 2: {'admin': [jane]}, nil: {nil: [nobody]}} with int64 keys
+func TestGroupMapped(t *testing.T) {
+	groups, err := GroupMapped(queryTenantUsers(t), []string{"tenant_id", "role"})
+	if err != nil {
+		t.Fatalf("GroupMapped() failed: %v", err)
+	}
+	if len(groups) != 3 {
+		t.Fatalf("expect 3 tenants, got %d", len(groups))
+	}
+	tenant1, ok := groups[int64(1)].(map[any]any)
+	if !ok {
+		t.Fatalf("expect int64 key 1 with nested map, got %#v", groups)
+	}
+	admins, _ := tenant1["admin"].([]map[string]any)
+	if len(admins) != 2 || admins[0]["user"] != "john" || admins[1]["user"] != "jill" {
+		t.Errorf("expect admins john and jill of tenant 1, got %v", admins)
+	}
+	if guests, _ := tenant1["guest"].([]map[string]any); len(guests) != 1 {
+		t.Errorf("expect 1 guest of tenant 1, got %v", guests)
+	}
+	if nobody, _ := groups[nil].(map[any]any)[nil].([]map[string]any); len(nobody) != 1 {
+		t.Errorf("expect NULL keys grouped under nil, got %v", groups[nil])
+	}
+}
+
+// TestGroupMappedByString
+// sql rows: same as TestGroupMapped
+// expect result: {'1': [john, jack, jill], '2': [jane], 'null': [nobody]} marshaled into json
+func TestGroupMappedByString(t *testing.T) {
+	groups, err := GroupMappedByString(queryTenantUsers(t), "tenant_id")
+	if err != nil {
+		t.Fatalf("GroupMappedByString() failed: %v", err)
+	}
+	if len(groups["1"]) != 3 || len(groups["2"]) != 1 || len(groups["null"]) != 1 {
+		t.Errorf("unexpected groups %v", groups)
+	}
+	if _, err = json.Marshal(groups); err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+}
+
+// TestGroupMappedByStringNullKey
+// sql rows: ['null', 'a'], [NULL, 'b']
+// expect result: collision error by default, {'null': [a], '<nil>': [b]} with WithNullGroupKey("<nil>")
+func TestGroupMappedByStringNullKey(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "name", DatabaseType: "VARCHAR"}, {Name: "user", DatabaseType: "VARCHAR"}},
+		Rows:    [][]any{{"null", "a"}, {nil, "b"}},
+	}
+	_, err := GroupMappedByString(queryFake(t, resultSet), "name")
+	if err == nil || !strings.Contains(err.Error(), "collides") {
+		t.Errorf("expect NULL key collision error, got %v", err)
+	}
+	groups, err := GroupMappedByString(queryFake(t, resultSet), "name", WithNullGroupKey("<nil>"))
+	if err != nil {
+		t.Fatalf("GroupMappedByString() failed: %v", err)
+	}
+	if len(groups["null"]) != 1 || groups["null"][0]["user"] != "a" || len(groups["<nil>"]) != 1 || groups["<nil>"][0]["user"] != "b" {
+		t.Errorf("expect separate groups of 'null' and NULL, got %v", groups)
+	}
+}
+
+// TestGroupMappedErrors
+// sql rows: same as TestGroupMapped
+// expect result: missing column and unhashable JSON key are reported
+func TestGroupMappedErrors(t *testing.T) {
+	if _, err := GroupMapped(queryTenantUsers(t), nil); err == nil {
+		t.Errorf("expect error without group column")
+	}
+	_, err := GroupMapped(queryTenantUsers(t), []string{"missing"})
+	if err == nil || !strings.Contains(err.Error(), "group column missing not found") {
+		t.Errorf("expect missing column error, got %v", err)
+	}
+	_, err = GroupMappedByString(queryTenantUsers(t), "tags")
+	if err == nil || !strings.Contains(err.Error(), "can't be group key") {
+		t.Errorf("expect unhashable key error, got %v", err)
+	}
+}
@@ -0,0 +1,315 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Strictness what ScanInto reports as error when columns and struct fields don't match
+type Strictness int
+
+const (
+	// StrictNone ignore columns without field and fields without column
+	StrictNone Strictness = 0
+	// StrictColumns error when any column has no field
+	StrictColumns Strictness = 1 << 0
+	// StrictFields error when any field has no column
+	StrictFields Strictness = 1 << 1
+	// StrictAll error for both
+	StrictAll = StrictColumns | StrictFields
+)
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// ScanInto scan rows into dest, which is pointer to slice of struct or slice of pointer to struct
+// columns are mapped to fields by `db:"name"` tag, or field name case-insensitively, `db:"-"` skips field
+// fields of embedded structs are mapped like fields of dest struct
+// values are converted like Scan first, then assigned to fields, with numeric conversion and json for JSON values
+// TIMESTAMP columns of time fields are converted as time.Time regardless of WithTimestampAs
+// unmapped columns and fields are ignored by default, see WithStrictness, WithNestedKeys is not supported
+// under WithLenient, value not assignable to its field is reported like failed conversion, the field keeps zero value
+// rows are appended to dest, dest is unchanged on error except *TruncatedError
+//
+//	var users []User
+//	err := ScanInto(rows, &users)
+func ScanInto(rows *sql.Rows, dest any, opts ...Option) error {
+	sliceValue := reflect.ValueOf(dest)
+	if sliceValue.Kind() != reflect.Pointer || sliceValue.IsNil() || sliceValue.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be pointer to slice of struct, got %T", dest)
+	}
+	sliceValue = sliceValue.Elem()
+	elemType := sliceValue.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("dest must be pointer to slice of struct, got %T", dest)
+	}
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return err
+	}
+	if scanner.cfg.nestSeparator != "" {
+		return errNestedKeys
+	}
+	fieldIndexes, err := mapFields(structType, scanner.colNames, scanner.cfg.strictness)
+	if err != nil {
+		return err
+	}
+	scanner.timestampsAsTime(structType, fieldIndexes)
+	allRows := reflect.MakeSlice(sliceValue.Type(), 0, 0)
+	for scanner.Next() {
+		structValue := reflect.New(structType).Elem()
+		for i, v := range scanner.Row() {
+			if fieldIndexes[i] == nil {
+				continue
+			}
+			field := structValue.FieldByIndex(fieldIndexes[i])
+			if err = assignValue(field, v); err != nil {
+				if !scanner.cfg.lenient {
+					return fmt.Errorf("assign column %s failed, %w", scanner.colNames[i], err)
+				}
+				// like a failed conversion, the field keeps zero value or nil
+				field.Set(reflect.Zero(field.Type()))
+				scanner.reportFailure(i, err)
+			}
+		}
+		if elemType.Kind() == reflect.Pointer {
+			structValue = structValue.Addr()
+		}
+		allRows = reflect.Append(allRows, structValue)
+	}
+	if err = scanner.Err(); err != nil && !isPartial(err) {
+		return err
+	}
+	sliceValue.Set(reflect.AppendSlice(sliceValue, allRows))
+	return err
+}
+
+// timestampsAsTime convert TIMESTAMP columns mapped to time fields as time.Time, whatever WithTimestampAs says
+// Unix number can't fill time.Time, and its unit is unknown by then
+func (s *Scanner) timestampsAsTime(structType reflect.Type, fieldIndexes [][]int) {
+	timeCfg := *s.cfg
+	timeCfg.timestampAs = TimestampAsTime
+	registry := s.cfg.registryOrDefault()
+	for i, colType := range s.colTypes {
+		if fieldIndexes[i] == nil || stripTypeParams(normalizeTypeName(colType.DatabaseTypeName())) != "TIMESTAMP" {
+			continue
+		}
+		if !isTimeType(structType.FieldByIndex(fieldIndexes[i]).Type) {
+			continue
+		}
+		if converter, ok := registry.lookup(colType.DatabaseTypeName()); ok && converter.Configure != nil {
+			s.replaceFuncs[i] = converter.replaceFunc(&timeCfg)
+		}
+	}
+}
+
+// isTimeType whether field holds time, like time.Time, *time.Time and sql.NullTime
+func isTimeType(fieldType reflect.Type) bool {
+	for fieldType.Kind() == reflect.Pointer {
+		fieldType = fieldType.Elem()
+	}
+	return fieldType == reflect.TypeOf(time.Time{}) || fieldType == reflect.TypeOf(sql.NullTime{})
+}
+
+// mapFields index of field for each column, nil when column has no field
+func mapFields(structType reflect.Type, colNames []string, strictness Strictness) ([][]int, error) {
+	var fields []structField
+	tagged := make(map[string]int)
+	named := make(map[string]int)
+	collectFields(structType, nil, &fields, tagged, named)
+
+	fieldIndexes := make([][]int, len(colNames))
+	// mapped by position in fields, a tag and a field name may be the same text
+	mapped := make([]bool, len(fields))
+	var unmappedColumns []string
+	for i, colName := range colNames {
+		field, ok := tagged[colName]
+		if !ok {
+			field, ok = named[strings.ToLower(colName)]
+		}
+		if !ok {
+			unmappedColumns = append(unmappedColumns, colName)
+			continue
+		}
+		fieldIndexes[i] = fields[field].index
+		mapped[field] = true
+	}
+	var unmappedFields []string
+	for i, field := range fields {
+		if !mapped[i] {
+			unmappedFields = append(unmappedFields, field.name)
+		}
+	}
+	var errs []error
+	if strictness&StrictColumns != 0 && len(unmappedColumns) > 0 {
+		errs = append(errs, fmt.Errorf("columns %s have no field in %s", strings.Join(unmappedColumns, ", "), structType))
+	}
+	if strictness&StrictFields != 0 && len(unmappedFields) > 0 {
+		errs = append(errs, fmt.Errorf("fields %s of %s have no column", strings.Join(unmappedFields, ", "), structType))
+	}
+	return fieldIndexes, errors.Join(errs...)
+}
+
+// structField field columns can map to, name is db tag or lower case field name
+type structField struct {
+	name  string
+	index []int
+}
+
+// collectFields collect exported fields, tagged and named map db tag or lower case name to position in fields
+// outer fields take precedence over embedded ones
+func collectFields(structType reflect.Type, parent []int, fields *[]structField, tagged, named map[string]int) {
+	var embedded []reflect.StructField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		index := append(append([]int(nil), parent...), i)
+		field.Index = index
+		tag := field.Tag.Get("db")
+		if tag == "-" {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && tag == "" {
+			embedded = append(embedded, field)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		keys, key := named, strings.ToLower(field.Name)
+		if tag != "" {
+			keys, key = tagged, tag
+		}
+		if _, ok := keys[key]; !ok {
+			keys[key] = len(*fields)
+			*fields = append(*fields, structField{name: key, index: index})
+		}
+	}
+	for _, field := range embedded {
+		collectFields(field.Type, field.Index, fields, tagged, named)
+	}
+}
+
+// assignValue assign converted value to field, nil keeps zero value
+// pointers are dereferenced and allocated as need, numbers are converted without overflow,
+// sql.Scanner fields scan the value, other values go through json like parsed JSON into struct
+func assignValue(field reflect.Value, v any) error {
+	if v == nil {
+		return nil
+	}
+	value := reflect.ValueOf(v)
+	if field.Kind() != reflect.Interface && value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+	// deref pointers of ValueAsPointer or custom converters
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+		if value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil
+			}
+			value = value.Elem()
+		}
+	}
+	if field.Kind() == reflect.Pointer {
+		if field.IsNil() {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+		return assignValue(field.Elem(), value.Interface())
+	}
+	if field.CanAddr() && field.Addr().Type().Implements(scannerType) {
+		src := value.Interface()
+		if d, ok := src.(Decimal); ok {
+			src = string(d)
+		}
+		return field.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+	if value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+	if converted, ok := convertNumber(value, field.Type()); ok {
+		field.Set(converted)
+		return nil
+	}
+	if value.Kind() == reflect.String && field.Kind() == reflect.String {
+		field.SetString(value.String())
+		return nil
+	}
+	bytes, err := json.Marshal(value.Interface())
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(bytes, field.Addr().Interface()); err != nil {
+		return fmt.Errorf("value of type %s is not assignable to %s, %w", value.Type(), field.Type(), err)
+	}
+	return nil
+}
+
+// convertNumber convert between integer and float kinds, false when not numbers or overflow
+func convertNumber(value reflect.Value, fieldType reflect.Type) (reflect.Value, bool) {
+	converted := reflect.New(fieldType).Elem()
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v := value.Int()
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if converted.OverflowInt(v) {
+				return converted, false
+			}
+			converted.SetInt(v)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if v < 0 || converted.OverflowUint(uint64(v)) {
+				return converted, false
+			}
+			converted.SetUint(uint64(v))
+		case reflect.Float32, reflect.Float64:
+			converted.SetFloat(float64(v))
+		default:
+			return converted, false
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v := value.Uint()
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v > 1<<63-1 || converted.OverflowInt(int64(v)) {
+				return converted, false
+			}
+			converted.SetInt(int64(v))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if converted.OverflowUint(v) {
+				return converted, false
+			}
+			converted.SetUint(v)
+		case reflect.Float32, reflect.Float64:
+			converted.SetFloat(float64(v))
+		default:
+			return converted, false
+		}
+	case reflect.Float32, reflect.Float64:
+		switch fieldType.Kind() {
+		case reflect.Float32, reflect.Float64:
+			converted.SetFloat(value.Float())
+		default:
+			return converted, false
+		}
+	default:
+		return converted, false
+	}
+	return converted, true
+}
@@ -0,0 +1,230 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+type intoBase struct {
+	ID int32 `db:"id"`
+}
+
+type intoUser struct {
+	intoBase
+	UserName string
+	Nickname *string `db:"nick_name"`
+	Age      uint8
+	Score    float64
+	Price    string
+	Created  time.Time `db:"created_at"`
+	LastSeen sql.NullTime
+	Tags     struct {
+		A int `json:"a"`
+	} `db:"tags"`
+	Options any    `db:"options"`
+	Ignored string `db:"-"`
+	private string
+}
+
+func queryIntoUsers(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "user_name", DatabaseType: "VARCHAR"},
+			{Name: "USERNAME", DatabaseType: "VARCHAR"},
+			{Name: "nick_name", DatabaseType: "VARCHAR"},
+			{Name: "age", DatabaseType: "UNSIGNED TINYINT"},
+			{Name: "score", DatabaseType: "INT"},
+			{Name: "price", DatabaseType: "DECIMAL"},
+			{Name: "created_at", DatabaseType: "DATETIME"},
+			{Name: "lastseen", DatabaseType: "TIMESTAMP"},
+			{Name: "tags", DatabaseType: "JSON"},
+			{Name: "options", DatabaseType: "JSON"},
+		},
+		Rows: [][]any{
+			{"1", "unmapped", "mysql", "my", "18", "90", "9.50", "2024-01-01 10:00:00", "2024-01-02 10:00:00", "{\"a\": 1}", "[1]"},
+			{"2", nil, nil, nil, nil, nil, nil, "2024-01-01 11:00:00", nil, "{}", nil},
+		},
+	})
+}
+
+// TestScanInto
+// sql rows: [1, 'unmapped', 'mysql', 'my', 18, 90, 9.50, '2024-01-01 10:00:00', '2024-01-02 10:00:00', '{"a": 1}', '[1]'],
+// [2, NULL, NULL, NULL, NULL, NULL, NULL, '2024-01-01 11:00:00', NULL, '{}', NULL]
+// expect result: fields of embedded struct, db tag and case-insensitive name are set with converted values, NULL keeps zero value
+func TestScanInto(t *testing.T) {
+	var users []intoUser
+	if err := ScanInto(queryIntoUsers(t), &users, WithLocation(time.UTC)); err != nil {
+		t.Fatalf("ScanInto() failed: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("expect 2 users, got %d", len(users))
+	}
+	user := users[0]
+	if user.ID != 1 || user.UserName != "mysql" || user.Nickname == nil || *user.Nickname != "my" ||
+		user.Age != 18 || user.Score != 90 || user.Price != "9.50" || user.Tags.A != 1 {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if !user.Created.Equal(time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect created 2024-01-01 10:00:00, got %v", user.Created)
+	}
+	if !user.LastSeen.Valid || !user.LastSeen.Time.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("expect last seen 2024-01-02 10:00:00, got %v", user.LastSeen)
+	}
+	if options, ok := user.Options.([]any); !ok || len(options) != 1 || options[0] != float64(1) {
+		t.Errorf("expect options [1], got %#v", user.Options)
+	}
+	user = users[1]
+	if user.ID != 2 || user.UserName != "" || user.Nickname != nil || user.Age != 0 || user.LastSeen.Valid || user.Options != nil {
+		t.Errorf("unexpected user %+v", user)
+	}
+}
+
+// TestScanIntoPointers
+// sql rows: same as TestScanInto
+// expect result: slice of pointer to struct is filled
+func TestScanIntoPointers(t *testing.T) {
+	var users []*intoUser
+	if err := ScanInto(queryIntoUsers(t), &users); err != nil {
+		t.Fatalf("ScanInto() failed: %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[1].ID != 2 {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+// TestScanIntoStrictness
+// sql rows: same as TestScanInto
+// expect result: user_name column has no field as name matches without underscore only, Ignored is skipped by tag
+// field named like tag of another field is unmapped when the column maps the tagged field
+func TestScanIntoStrictness(t *testing.T) {
+	if StrictColumns != 1 || StrictFields != 2 || StrictAll != 3 {
+		t.Errorf("expect strictness flags 1, 2, 3, got %d, %d, %d", StrictColumns, StrictFields, StrictAll)
+	}
+	var users []intoUser
+	err := ScanInto(queryIntoUsers(t), &users, WithStrictness(StrictColumns))
+	if err == nil || !strings.Contains(err.Error(), "columns user_name have no field") {
+		t.Errorf("expect unmapped column error, got %v", err)
+	}
+
+	type partialUser struct {
+		ID      int64 `db:"id"`
+		Deleted bool
+	}
+	var partialUsers []partialUser
+	err = ScanInto(queryIntoUsers(t), &partialUsers, WithStrictness(StrictFields))
+	if err == nil || !strings.Contains(err.Error(), "fields deleted of") {
+		t.Errorf("expect unmapped field error, got %v", err)
+	}
+	if err = ScanInto(queryIntoUsers(t), &partialUsers); err != nil || len(partialUsers) != 2 {
+		t.Errorf("expect unmapped columns and fields ignored, got %v", err)
+	}
+
+	// tag of A and name of ID are the same text, column id maps A only
+	type collidedUser struct {
+		A  int64 `db:"id"`
+		ID int64
+	}
+	var collidedUsers []collidedUser
+	err = ScanInto(queryIntoUsers(t), &collidedUsers, WithStrictness(StrictFields))
+	if err == nil || !strings.Contains(err.Error(), "fields id of") {
+		t.Errorf("expect unmapped field ID error, got %v", err)
+	}
+}
+
+// TestScanIntoErrors
+// sql rows: same as TestScanInto
+// expect result: invalid dest and unassignable value are reported
+func TestScanIntoErrors(t *testing.T) {
+	var notSlice intoUser
+	if err := ScanInto(queryIntoUsers(t), &notSlice); err == nil {
+		t.Errorf("expect error for pointer to struct")
+	}
+	var notStruct []int
+	if err := ScanInto(queryIntoUsers(t), &notStruct); err == nil {
+		t.Errorf("expect error for slice of int")
+	}
+	type mismatchUser struct {
+		ID int64 `db:"id"`
+		// created_at is time.Time, which can't be assigned to a number
+		Created int8 `db:"created_at"`
+	}
+	var users []mismatchUser
+	err := ScanInto(queryIntoUsers(t), &users)
+	if err == nil || !strings.Contains(err.Error(), "assign column created_at failed") {
+		t.Errorf("expect assign error, got %v", err)
+	}
+}
+
+// TestConvertNumber
+// expect result: integers convert between kinds unless overflow, floats don't become integers
+func TestConvertNumber(t *testing.T) {
+	var i8 int8
+	var u8 uint8
+	var f float32
+	cases := []struct {
+		name   string
+		value  any
+		target any
+		ok     bool
+	}{
+		{"int64 to int8", int64(127), i8, true},
+		{"int64 overflow int8", int64(128), i8, false},
+		{"negative to uint8", int64(-1), u8, false},
+		{"uint64 to int8", uint64(1), i8, true},
+		{"uint64 overflow int64", uint64(1 << 63), int64(0), false},
+		{"int64 to float32", int64(1), f, true},
+		{"float64 to int8", float64(1), i8, false},
+		{"string to int8", "1", i8, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, ok := convertNumber(reflect.ValueOf(c.value), reflect.TypeOf(c.target))
+			if ok != c.ok {
+				t.Errorf("expect %v, got %v", c.ok, ok)
+			}
+		})
+	}
+}
+
+// TestScanIntoTimestampDefault
+// sql rows: [1, '2024-01-02 10:00:00'] of columns id BIGINT, lastseen TIMESTAMP
+// expect result: with default options TIMESTAMP fills time.Time and sql.NullTime fields, number field still gets Unix seconds
+func TestScanIntoTimestampDefault(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "created_at", DatabaseType: "TIMESTAMP"},
+			{Name: "lastseen", DatabaseType: "TIMESTAMP"},
+			{Name: "updated_at", DatabaseType: "TIMESTAMP"},
+		},
+		Rows: [][]any{{"1", "2024-01-02 10:00:00", "2024-01-02 10:00:00", "2024-01-02 10:00:00"}},
+	}
+	type timestampUser struct {
+		ID        int64     `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+		LastSeen  sql.NullTime
+		UpdatedAt int64 `db:"updated_at"`
+	}
+	var users []timestampUser
+	if err := ScanInto(queryFake(t, resultSet), &users); err != nil {
+		t.Fatalf("ScanInto() failed: %v", err)
+	}
+	expect := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	if len(users) != 1 || !users[0].CreatedAt.Equal(expect) || !users[0].LastSeen.Valid || !users[0].LastSeen.Time.Equal(expect) {
+		t.Errorf("expect time %v, got %+v", expect, users)
+	}
+	if users[0].UpdatedAt != expect.Unix() {
+		t.Errorf("expect unix %d, got %d", expect.Unix(), users[0].UpdatedAt)
+	}
+}
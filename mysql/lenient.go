@@ -0,0 +1,51 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+// FallbackMode value of cell failed to convert under WithLenient
+type FallbackMode int
+
+const (
+	// FallbackAsRaw keep raw text like columns without converter
+	FallbackAsRaw FallbackMode = iota
+	// FallbackAsNil convert to nil like NULL
+	FallbackAsNil
+)
+
+// ConversionReport conversion failures collected by WithLenient, reset it before reuse
+type ConversionReport struct {
+	// MaxErrors max failures kept in Errors, 0 keeps all
+	MaxErrors int
+	// Errors failures with row and column in scan order
+	Errors []*ConversionError
+	// Failed count of all failures, including those beyond MaxErrors
+	Failed int
+}
+
+// Truncated whether failures beyond MaxErrors were dropped
+func (r *ConversionReport) Truncated() bool {
+	return r.Failed > len(r.Errors)
+}
+
+func (r *ConversionReport) add(err *ConversionError) {
+	r.Failed++
+	if r.MaxErrors > 0 && len(r.Errors) >= r.MaxErrors {
+		return
+	}
+	r.Errors = append(r.Errors, err)
+}
+
+// WithLenient keep scanning when converter fails, the cell falls back following mode and the failure goes into report
+// report can be nil when failures don't matter
+//
+//	report := &ConversionReport{MaxErrors: 100}
+//	allRows, err := Scan(rows, WithLenient(FallbackAsRaw, report))
+func WithLenient(fallback FallbackMode, report *ConversionReport) Option {
+	return func(cfg *config) {
+		cfg.lenient = true
+		cfg.fallback = fallback
+		cfg.report = report
+	}
+}
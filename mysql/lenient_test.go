@@ -0,0 +1,118 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+func queryDirtyRows(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "amount", DatabaseType: "INT"},
+			{Name: "created_at", DatabaseType: "DATETIME"},
+		},
+		Rows: [][]any{
+			{"1", "x", "2024-01-01 10:00:00"},
+			{"2", "20", "yesterday"},
+			{"3", "y", nil},
+		},
+	})
+}
+
+// TestWithLenient
+// sql rows: [1, 'x', '2024-01-01 10:00:00'], [2, 20, 'yesterday'], [3, 'y', NULL]
+// expect result: all rows scanned, failing cells keep raw text, report has 3 failures in scan order
+func TestWithLenient(t *testing.T) {
+	report := &ConversionReport{}
+	mappedRows, err := ScanMapped(queryDirtyRows(t), WithLenient(FallbackAsRaw, report))
+	if err != nil {
+		t.Fatalf("ScanMapped() failed: %v", err)
+	}
+	if len(mappedRows) != 3 {
+		t.Fatalf("expect 3 rows, got %d", len(mappedRows))
+	}
+	if mappedRows[0]["amount"] != "x" || mappedRows[1]["amount"] != int64(20) || mappedRows[1]["created_at"] != "yesterday" {
+		t.Errorf("unexpected rows %v", mappedRows)
+	}
+	expectCells := []struct {
+		row    int
+		column string
+	}{{0, "amount"}, {1, "created_at"}, {2, "amount"}}
+	if report.Failed != 3 || len(report.Errors) != 3 || report.Truncated() {
+		t.Fatalf("expect 3 failures, got %d of %d", len(report.Errors), report.Failed)
+	}
+	for i, cell := range expectCells {
+		if report.Errors[i].Row != cell.row || report.Errors[i].Column != cell.column {
+			t.Errorf("failure %d expect row %d column %s, got %+v", i, cell.row, cell.column, report.Errors[i])
+		}
+	}
+}
+
+// TestWithLenientNil
+// sql rows: same as TestWithLenient
+// expect result: failing cells are nil, report keeps MaxErrors failures and counts all
+func TestWithLenientNil(t *testing.T) {
+	report := &ConversionReport{MaxErrors: 2}
+	allRows, err := Scan(queryDirtyRows(t), WithLenient(FallbackAsNil, report))
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	if allRows[0][1] != nil || allRows[1][2] != nil || allRows[2][1] != nil {
+		t.Errorf("expect failing cells nil, got %v", allRows)
+	}
+	if report.Failed != 3 || len(report.Errors) != 2 || !report.Truncated() {
+		t.Errorf("expect 2 of 3 failures kept, got %d of %d", len(report.Errors), report.Failed)
+	}
+
+	// report is optional
+	if _, err = Scan(queryDirtyRows(t), WithLenient(FallbackAsNil, nil)); err != nil {
+		t.Errorf("Scan() without report failed: %v", err)
+	}
+}
+
+// TestWithLenientScanInto
+// sql rows: same as TestWithLenient
+// expect result: all rows scanned into structs, fields of failing cells keep zero value or nil, report has 3 failures
+// values not assignable to their fields are reported like failed conversions
+func TestWithLenientScanInto(t *testing.T) {
+	type dirtyRow struct {
+		ID        int64
+		Amount    int64
+		CreatedAt *time.Time `db:"created_at"`
+	}
+	for _, fallback := range []FallbackMode{FallbackAsRaw, FallbackAsNil} {
+		report := &ConversionReport{}
+		var dirtyRows []dirtyRow
+		if err := ScanInto(queryDirtyRows(t), &dirtyRows, WithLenient(fallback, report)); err != nil {
+			t.Fatalf("fallback %d ScanInto() failed: %v", fallback, err)
+		}
+		if len(dirtyRows) != 3 || dirtyRows[0].Amount != 0 || dirtyRows[1].Amount != 20 || dirtyRows[1].CreatedAt != nil || dirtyRows[0].CreatedAt == nil {
+			t.Errorf("fallback %d unexpected rows %+v", fallback, dirtyRows)
+		}
+		if report.Failed != 3 || report.Errors[0].Row != 0 || report.Errors[0].Column != "amount" || report.Errors[0].Raw != "x" {
+			t.Errorf("fallback %d expect 3 failures from row 0 column amount, got %d %+v", fallback, report.Failed, report.Errors)
+		}
+	}
+
+	// converted BIGINT not assignable to string field is reported after conversion failures of the row
+	type mistypedRow struct {
+		ID string
+	}
+	report := &ConversionReport{}
+	var mistypedRows []mistypedRow
+	if err := ScanInto(queryDirtyRows(t), &mistypedRows, WithLenient(FallbackAsNil, report)); err != nil {
+		t.Fatalf("ScanInto() failed: %v", err)
+	}
+	if len(mistypedRows) != 3 || mistypedRows[0].ID != "" || report.Failed != 6 || report.Errors[1].Column != "id" || report.Errors[1].Raw != "1" {
+		t.Errorf("expect id failures reported, got %+v %d %+v", mistypedRows, report.Failed, report.Errors)
+	}
+}
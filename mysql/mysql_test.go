@@ -7,91 +7,51 @@ package mysql
 import (
 	"database/sql"
 	"encoding/json"
-	_ "github.com/go-sql-driver/mysql"
 	"slices"
 	"testing"
-)
-
-const mysqlConnectionStr = "root:******@tcp(127.0.0.1:3306)/test?charset=utf8"
+	"time"
 
-func newConnection() (*sql.DB, error) {
-	return sql.Open("mysql", mysqlConnectionStr)
-}
-
-func createTable() error {
-	db, err := newConnection()
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS `t1`")
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("CREATE TABLE t1(id int, name varchar(18))")
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("DROP TABLE IF EXISTS `t_json`")
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("CREATE TABLE t_json(id int, content json)")
-	return err
-}
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
 
-func insertData() error {
-	db, err := sql.Open("mysql", mysqlConnectionStr)
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("INSERT INTO t1(id, name) VALUES (1, 'mysql')")
-	if err != nil {
-		return err
-	}
-	_, err = db.Exec("INSERT INTO t_json(id, content) VALUES (1, '{\"name\":\"mysql\", \"version\": 5.7, \"enabled\": true}'), (2, '[\"5.7\", \"8.0\"]'), (3, '[{\"name\":\"mysql\", \"version\": 5.7}]')")
-	return err
+// t1 rows of `CREATE TABLE t1(id int, name varchar(18))` as go-sql-driver 1.8 returns them
+// integers are parsed by driver even on text protocol, strings are []byte
+var t1 = mysqltest.ResultSet{
+	Columns: []mysqltest.Column{
+		{Name: "id", DatabaseType: "INT"},
+		{Name: "name", DatabaseType: "VARCHAR", Length: 72},
+	},
+	Rows: [][]any{{int64(1), "mysql"}},
 }
 
-func prepare() error {
-	err := createTable()
-	if err != nil {
-		return err
-	}
-	err = insertData()
-	return err
+// tJSON rows of `CREATE TABLE t_json(id int, content json)`
+var tJSON = mysqltest.ResultSet{
+	Columns: []mysqltest.Column{
+		{Name: "id", DatabaseType: "INT"},
+		{Name: "content", DatabaseType: "JSON"},
+	},
+	Rows: [][]any{
+		{int64(1), "{\"name\":\"mysql\", \"version\": 5.7, \"enabled\": true}"},
+		{int64(2), "[\"5.7\", \"8.0\"]"},
+		{int64(3), "[{\"name\":\"mysql\", \"version\": 5.7}]"},
+	},
 }
 
-func queryT1() (*sql.Rows, error) {
-	db, err := newConnection()
-	if err != nil {
-		return nil, err
-	}
-	rows, err := db.Query("select * from t1")
-	return rows, err
+func queryT1(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, t1)
 }
 
-func queryTJSON() (*sql.Rows, error) {
-	db, err := newConnection()
-	if err != nil {
-		return nil, err
-	}
-	rows, err := db.Query("select * from t_json")
-	return rows, err
+func queryTJSON(t *testing.T) *sql.Rows {
+	t.Helper()
+	return queryFake(t, tJSON)
 }
 
 // TestMySQLAnonymousScan
 // queryT1 row: [1, 'mysql']
-// expect result: [[1, 'mysql']]
-// actually result: [[1,"bXlzcWw="]]
+// expect result: [[1,"bXlzcWw="]], scanning into any keeps []byte of VARCHAR, which is marshaled as base64
 func TestMySQLAnonymousScan(t *testing.T) {
-	err := prepare()
-	if err != nil {
-		t.Errorf("prepare() failed: %v", err)
-	}
-	rows, err := queryT1()
-	if err != nil {
-		t.Errorf("queryT1() failed: %v", err)
-	}
+	rows := queryT1(t)
 	cols, err := rows.Columns()
 	if err != nil {
 		t.Errorf("rows.Columns() failed: %v", err)
@@ -114,23 +74,17 @@ func TestMySQLAnonymousScan(t *testing.T) {
 	if err != nil {
 		t.Errorf("json.Marshal() failed: %v", err)
 	}
-	t.Errorf(string(bytes))
+	const rawJson = "[[1,\"bXlzcWw=\"]]"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
 }
 
 // TestScanAnonymousRows
 // queryT1 row: [1, 'mysql']
 // expect result: [[1,"mysql"]]
-// actually result: [[1,"mysql"]]
 func TestScanAnonymousRows(t *testing.T) {
-	err := prepare()
-	if err != nil {
-		t.Errorf("prepare() failed: %v", err)
-	}
-	rows, err := queryT1()
-	if err != nil {
-		t.Errorf("queryT1() failed: %s", err)
-	}
-	anonymousRows, err := ScanAnonymousRows(rows)
+	anonymousRows, err := ScanAnonymousRows(queryT1(t))
 	if err != nil {
 		t.Errorf("ScanAnonymousRows() failed: %v", err)
 	}
@@ -140,24 +94,15 @@ func TestScanAnonymousRows(t *testing.T) {
 	}
 	const rawJson = "[[1,\"mysql\"]]"
 	if string(bytes) != rawJson {
-		t.Errorf("mapped json doesn't match")
+		t.Errorf("expect %s, got %s", rawJson, bytes)
 	}
 }
 
 // TestScanAnonymousMappedRows
 // queryT1 row: [1, 'mysql']
 // expect result: [{"id":1,"name":"mysql"}]
-// actually result: [{"id":1,"name":"mysql"}]
 func TestScanAnonymousMappedRows(t *testing.T) {
-	err := prepare()
-	if err != nil {
-		t.Errorf("prepare() failed: %v", err)
-	}
-	rows, err := queryT1()
-	if err != nil {
-		t.Errorf("queryT1() failed: %s", err)
-	}
-	mappedRows, err := ScanAnonymousMappedRows(rows)
+	mappedRows, err := ScanAnonymousMappedRows(queryT1(t))
 	if err != nil {
 		t.Errorf("ScanAnonymousMappedRows() failed: %v", err)
 	}
@@ -168,24 +113,15 @@ func TestScanAnonymousMappedRows(t *testing.T) {
 	}
 	const mappedJson = "[{\"id\":1,\"name\":\"mysql\"}]"
 	if string(bytes) != mappedJson {
-		t.Errorf("mapped json doesn't match")
+		t.Errorf("expect %s, got %s", mappedJson, bytes)
 	}
 }
 
 // TestMySQLJsonColumn
 // queryTJSON rows: (1, '{"name":"mysql", "version": 5.7, "enabled": true}'), (2, '["5.7", "8.0"]'), (3, '[{"name":"mysql", "version": 5.7}]')
-// expect result: [{"content":{"enabled":true,"name":"mysql","version":5.7},"id":1},{"content":["5.7","8.0"],"id":2},{"content":[{"name":"mysql","version":5.7}],"id":3}]
-// actually result: [{"content":{"enabled":true,"name":"mysql","version":5.7},"id":1},{"content":["5.7","8.0"],"id":2},{"content":[{"name":"mysql","version":5.7}],"id":3}]
+// expect result: [[1,{"enabled":true,"name":"mysql","version":5.7}],[2,["5.7","8.0"]],[3,[{"name":"mysql","version":5.7}]]]
 func TestMySQLJsonColumn(t *testing.T) {
-	err := prepare()
-	if err != nil {
-		t.Errorf("prepare() failed: %v", err)
-	}
-	rows, err := queryTJSON()
-	if err != nil {
-		t.Errorf("queryTJSON() failed: %v", err)
-	}
-	mappedRows, err := ScanAnonymousRows(rows)
+	mappedRows, err := ScanAnonymousRows(queryTJSON(t))
 	if err != nil {
 		t.Errorf("ScanAnonymousRows() failed: %v", err)
 	}
@@ -195,24 +131,15 @@ func TestMySQLJsonColumn(t *testing.T) {
 	}
 	const rawJson = "[[1,{\"enabled\":true,\"name\":\"mysql\",\"version\":5.7}],[2,[\"5.7\",\"8.0\"]],[3,[{\"name\":\"mysql\",\"version\":5.7}]]]"
 	if string(bytes) != rawJson {
-		t.Errorf("mapped json doesn't match")
+		t.Errorf("expect %s, got %s", rawJson, bytes)
 	}
 }
 
 // TestMySQLMappedJsonColumn
 // queryTJSON rows: (1, '{"name":"mysql", "version": 5.7, "enabled": true}'), (2, '["5.7", "8.0"]'), (3, '[{"name":"mysql", "version": 5.7}]')
-// expect result: [[1,{"enabled":true,"name":"mysql","version":5.7}],[2,["5.7","8.0"]],[3,[{"name":"mysql","version":5.7}]]]
-// actually result: [[1,{"enabled":true,"name":"mysql","version":5.7}],[2,["5.7","8.0"]],[3,[{"name":"mysql","version":5.7}]]]
+// expect result: [{"content":{"enabled":true,"name":"mysql","version":5.7},"id":1},{"content":["5.7","8.0"],"id":2},{"content":[{"name":"mysql","version":5.7}],"id":3}]
 func TestMySQLMappedJsonColumn(t *testing.T) {
-	err := prepare()
-	if err != nil {
-		t.Errorf("prepare() failed: %v", err)
-	}
-	rows, err := queryTJSON()
-	if err != nil {
-		t.Errorf("queryTJSON() failed: %v", err)
-	}
-	mappedRows, err := ScanAnonymousMappedRows(rows)
+	mappedRows, err := ScanAnonymousMappedRows(queryTJSON(t))
 	if err != nil {
 		t.Errorf("ScanAnonymousMappedRows() failed: %v", err)
 	}
@@ -222,7 +149,87 @@ func TestMySQLMappedJsonColumn(t *testing.T) {
 	}
 	const rawJson = "[{\"content\":{\"enabled\":true,\"name\":\"mysql\",\"version\":5.7},\"id\":1},{\"content\":[\"5.7\",\"8.0\"],\"id\":2},{\"content\":[{\"name\":\"mysql\",\"version\":5.7}],\"id\":3}]"
 	if string(bytes) != rawJson {
-		t.Errorf("mapped json doesn't match")
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+}
+
+// mysqlTypeCases raw value of every built-in converter type as go-sql-driver sends it, and its converted json
+var mysqlTypeCases = []struct {
+	databaseType string
+	raw          any
+	expectJson   string
+}{
+	{"DOUBLE", "9.5", "9.5"},
+	{"FLOAT", "1.25", "1.25"},
+	{"DECIMAL", "12345678901234567890.123", "12345678901234567890.123"},
+	{"TINYINT", "-128", "-128"},
+	{"SMALLINT", "-32768", "-32768"},
+	{"MEDIUMINT", "-8388608", "-8388608"},
+	{"INT", int64(-2147483648), "-2147483648"},
+	{"BIGINT", "-9223372036854775808", "-9223372036854775808"},
+	{"YEAR", "2024", "2024"},
+	{"UNSIGNED TINYINT", "255", "255"},
+	{"UNSIGNED SMALLINT", "65535", "65535"},
+	{"UNSIGNED MEDIUMINT", "16777215", "16777215"},
+	{"UNSIGNED INT", "4294967295", "4294967295"},
+	{"UNSIGNED BIGINT", uint64(18446744073709551615), "18446744073709551615"},
+	{"BIT", "\x01\x00", "256"},
+	{"TIMESTAMP", "2024-01-01 10:00:00", "1704103200"},
+	{"DATETIME", "2024-01-01 10:00:00.123456", "\"2024-01-01T10:00:00.123456Z\""},
+	{"DATE", "2024-01-01", "\"2024-01-01T00:00:00Z\""},
+	{"TIME", "-838:59:59", "\"-838:59:59\""},
+	{"SET", "a,b", "[\"a\",\"b\"]"},
+	{"ENUM", "a", "\"a\""},
+	{"CHAR", "mysql", "\"mysql\""},
+	{"VARCHAR", "mysql", "\"mysql\""},
+	{"TINYTEXT", "mysql", "\"mysql\""},
+	{"TEXT", "mysql", "\"mysql\""},
+	{"MEDIUMTEXT", "mysql", "\"mysql\""},
+	{"LONGTEXT", "mysql", "\"mysql\""},
+	{"JSON", "{\"a\": [1, true]}", "{\"a\":[1,true]}"},
+	{"BINARY", "\xff\x00\x01", "\"/wAB\""},
+	{"VARBINARY", "\xff\x00\x01", "\"/wAB\""},
+	{"TINYBLOB", "\xff\x00\x01", "\"/wAB\""},
+	{"BLOB", "\xff\x00\x01", "\"/wAB\""},
+	{"MEDIUMBLOB", "\xff\x00\x01", "\"/wAB\""},
+	{"LONGBLOB", "\xff\x00\x01", "\"/wAB\""},
+	{"GEOMETRY", "raw", "\"raw\""},
+}
+
+// TestMySQLTypes
+// rows: a column of every type in mysqlTypeCases, then NULL of each
+// expect result: converted json of each case, null of NULL, every built-in converter covered
+func TestMySQLTypes(t *testing.T) {
+	resultSet := mysqltest.ResultSet{Rows: [][]any{{}, {}}}
+	covered := make(map[string]bool)
+	for _, c := range mysqlTypeCases {
+		resultSet.Columns = append(resultSet.Columns, mysqltest.Column{Name: c.databaseType, DatabaseType: c.databaseType})
+		resultSet.Rows[0] = append(resultSet.Rows[0], c.raw)
+		resultSet.Rows[1] = append(resultSet.Rows[1], nil)
+		covered[c.databaseType] = true
+	}
+	for _, converter := range mysqlTypeConverters {
+		if !covered[converter.MySQLType] {
+			t.Errorf("no case of %s", converter.MySQLType)
+		}
+	}
+
+	allRows, err := Scan(queryFake(t, resultSet), WithLocation(time.UTC))
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	for i, c := range mysqlTypeCases {
+		bytes, err := json.Marshal(allRows[0][i])
+		if err != nil {
+			t.Errorf("%s json.Marshal() failed: %v", c.databaseType, err)
+			continue
+		}
+		if string(bytes) != c.expectJson {
+			t.Errorf("%s expect %s, got %s", c.databaseType, c.expectJson, bytes)
+		}
+		if allRows[1][i] != nil {
+			t.Errorf("%s expect nil of NULL, got %#v", c.databaseType, allRows[1][i])
+		}
 	}
 }
 
@@ -231,14 +238,7 @@ func TestMySQLMappedJsonColumn(t *testing.T) {
 // expect result: {"schema":{"fields":[{"name":"id","type":"number","typeInfo":{"frame":"int64","nullable":true}},{"name":"name","type":"string","typeInfo":{"frame":"string","nullable":true}}]},"data":{"values":[[1],["mysql"]]}}
 // actually result: {"schema":{"fields":[{"name":"id","type":"number","typeInfo":{"frame":"int64","nullable":true}},{"name":"name","type":"string","typeInfo":{"frame":"string","nullable":true}}]},"data":{"values":[[1],["mysql"]]}}
 //func TestMySQLScanToGrafanaFrames(t *testing.T) {
-//	err := prepare()
-//	if err != nil {
-//		t.Errorf("prepare() failed: %v", err)
-//	}
-//	rows, err := queryT1()
-//	if err != nil {
-//		t.Errorf("queryT1() failed: %v", err)
-//	}
+//	rows := queryT1(t)
 //
 //	frames, err := sqlutil.FrameFromRows(rows, 8888, sqlutil.ToConverters(grafanaMySQLTypeConverters...)...)
 //	if err != nil {
@@ -0,0 +1,228 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+// Package mysqltest in-memory database/sql driver for testing code that scans mysql rows without a mysql server
+// columns report whatever database type name, scan type, nullability, length, precision and scale given
+//
+//	db := mysqltest.Open(mysqltest.ResultSet{
+//		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "name", DatabaseType: "VARCHAR"}},
+//		Rows:    [][]any{{"1", "mysql"}, {"2", nil}},
+//	})
+//	rows, err := db.Query("SELECT id, name FROM t1")
+package mysqltest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+)
+
+// Nullability nullability reported by column
+type Nullability int
+
+const (
+	// Nullable column may be NULL
+	Nullable Nullability = iota
+	// NotNull column is NOT NULL
+	NotNull
+	// NullableUnknown driver doesn't know, like expressions of some drivers
+	NullableUnknown
+)
+
+// Column column of result set, reported by sql.ColumnType
+type Column struct {
+	Name string
+	// DatabaseType reported by DatabaseTypeName, like BIGINT, UNSIGNED INT, DECIMAL as go-sql-driver does
+	DatabaseType string
+	// ScanType reported by ScanType, nil reports any
+	ScanType    reflect.Type
+	Nullability Nullability
+	// Length reported when not zero, like length of VARCHAR
+	Length int64
+	// Precision and Scale reported when Precision is not zero, like DECIMAL(10,2)
+	Precision int64
+	Scale     int64
+}
+
+// ResultSet columns and rows returned by query
+// string values are sent as []byte like mysql text protocol, other values like int64, time.Time and nil as is
+// Err is returned after rows instead of end of rows, like connection dropped in the middle of result
+type ResultSet struct {
+	Columns []Column
+	Rows    [][]any
+	Err     error
+}
+
+// QueryFunc result sets of query, error fails the query itself
+type QueryFunc func(query string, args []driver.NamedValue) ([]ResultSet, error)
+
+// Connector driver.Connector whose connections answer queries with Query
+type Connector struct {
+	Query QueryFunc
+}
+
+// Open open db whose every query returns given result sets, later ones are read by rows.NextResultSet
+func Open(resultSets ...ResultSet) *sql.DB {
+	return OpenFunc(func(string, []driver.NamedValue) ([]ResultSet, error) {
+		return resultSets, nil
+	})
+}
+
+// OpenFunc open db answering queries with query
+func OpenFunc(query QueryFunc) *sql.DB {
+	return sql.OpenDB(&Connector{Query: query})
+}
+
+// Connect driver.Connector
+func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{query: c.Query}, nil
+}
+
+// Driver driver.Connector
+func (c *Connector) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("mysqltest driver opens by connector only")
+}
+
+type conn struct {
+	query QueryFunc
+}
+
+var _ driver.QueryerContext = (*conn)(nil)
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("mysqltest driver doesn't support prepare")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+// Begin given transaction doing nothing, queries in it are served by the same QueryFunc
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	resultSets, err := c.query(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(resultSets) == 0 {
+		return nil, errors.New("mysqltest query returns no result set")
+	}
+	return &rows{resultSets: resultSets}, nil
+}
+
+var (
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+	_ driver.RowsColumnTypeLength           = (*rows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*rows)(nil)
+	_ driver.RowsNextResultSet              = (*rows)(nil)
+)
+
+type rows struct {
+	resultSets []ResultSet
+	set        int
+	row        int
+}
+
+func (r *rows) column(index int) Column {
+	return r.resultSets[r.set].Columns[index]
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.resultSets[r.set].Columns))
+	for i, column := range r.resultSets[r.set].Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.column(index).DatabaseType
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	if scanType := r.column(index).ScanType; scanType != nil {
+		return scanType
+	}
+	return reflect.TypeOf((*any)(nil)).Elem()
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	switch r.column(index).Nullability {
+	case NotNull:
+		return false, true
+	case NullableUnknown:
+		return false, false
+	default:
+		return true, true
+	}
+}
+
+func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	column := r.column(index)
+	return column.Length, column.Length != 0
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	column := r.column(index)
+	return column.Precision, column.Scale, column.Precision != 0
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	resultSet := r.resultSets[r.set]
+	if r.row >= len(resultSet.Rows) {
+		if resultSet.Err != nil {
+			return resultSet.Err
+		}
+		return io.EOF
+	}
+	for i, v := range resultSet.Rows[r.row] {
+		if s, ok := v.(string); ok {
+			v = []byte(s)
+		}
+		dest[i] = v
+	}
+	r.row++
+	return nil
+}
+
+func (r *rows) HasNextResultSet() bool {
+	return r.set+1 < len(r.resultSets)
+}
+
+func (r *rows) NextResultSet() error {
+	if !r.HasNextResultSet() {
+		return io.EOF
+	}
+	r.set++
+	r.row = 0
+	return nil
+}
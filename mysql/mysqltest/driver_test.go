@@ -0,0 +1,134 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysqltest
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestColumnTypes
+// columns: id BIGINT NOT NULL, name VARCHAR(18) nullable unknown, price DECIMAL(10,2) scanned as sql.NullString
+// expect result: column types report them as given
+func TestColumnTypes(t *testing.T) {
+	db := Open(ResultSet{
+		Columns: []Column{
+			{Name: "id", DatabaseType: "BIGINT", Nullability: NotNull},
+			{Name: "name", DatabaseType: "VARCHAR", Nullability: NullableUnknown, Length: 72},
+			{Name: "price", DatabaseType: "DECIMAL", ScanType: reflect.TypeOf(sql.NullString{}), Precision: 10, Scale: 2},
+		},
+	})
+	defer db.Close()
+	rows, err := db.Query("SELECT id, name, price FROM t")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	defer rows.Close()
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		t.Fatalf("rows.ColumnTypes() failed: %v", err)
+	}
+	if colTypes[0].Name() != "id" || colTypes[0].DatabaseTypeName() != "BIGINT" {
+		t.Errorf("unexpected column %s %s", colTypes[0].Name(), colTypes[0].DatabaseTypeName())
+	}
+	if nullable, ok := colTypes[0].Nullable(); nullable || !ok {
+		t.Errorf("expect id NOT NULL, got %v %v", nullable, ok)
+	}
+	if _, ok := colTypes[1].Nullable(); ok {
+		t.Errorf("expect nullability of name unknown")
+	}
+	if length, ok := colTypes[1].Length(); length != 72 || !ok {
+		t.Errorf("expect length of name 72, got %d %v", length, ok)
+	}
+	if _, ok := colTypes[0].Length(); ok {
+		t.Errorf("expect length of id unknown")
+	}
+	if precision, scale, ok := colTypes[2].DecimalSize(); precision != 10 || scale != 2 || !ok {
+		t.Errorf("expect DECIMAL(10,2), got %d %d %v", precision, scale, ok)
+	}
+	if colTypes[2].ScanType() != reflect.TypeOf(sql.NullString{}) {
+		t.Errorf("expect scan type sql.NullString, got %v", colTypes[2].ScanType())
+	}
+	if colTypes[0].ScanType() != reflect.TypeOf((*any)(nil)).Elem() {
+		t.Errorf("expect scan type any, got %v", colTypes[0].ScanType())
+	}
+}
+
+// TestRows
+// result sets: [1, 'a'], [2, NULL] then [3]
+// expect result: string values as []byte, nil as NULL, next result set, error after rows
+func TestRows(t *testing.T) {
+	errDropped := errors.New("connection dropped")
+	db := Open(
+		ResultSet{
+			Columns: []Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "name", DatabaseType: "VARCHAR"}},
+			Rows:    [][]any{{int64(1), "a"}, {int64(2), nil}},
+		},
+		ResultSet{
+			Columns: []Column{{Name: "id", DatabaseType: "BIGINT"}},
+			Rows:    [][]any{{int64(3)}},
+			Err:     errDropped,
+		},
+	)
+	defer db.Close()
+	rows, err := db.Query("CALL p()")
+	if err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	defer rows.Close()
+	var names []any
+	for rows.Next() {
+		var id int64
+		var name any
+		if err = rows.Scan(&id, &name); err != nil {
+			t.Fatalf("rows.Scan() failed: %v", err)
+		}
+		names = append(names, name)
+	}
+	if len(names) != 2 || !reflect.DeepEqual(names[0], []byte("a")) || names[1] != nil {
+		t.Errorf("expect []byte a and nil, got %v", names)
+	}
+	if !rows.NextResultSet() {
+		t.Fatalf("expect next result set, got %v", rows.Err())
+	}
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	if count != 1 || !errors.Is(rows.Err(), errDropped) {
+		t.Errorf("expect 1 row then connection dropped, got %d %v", count, rows.Err())
+	}
+}
+
+// TestOpenFunc
+// expect result: query and args are passed to QueryFunc, its error fails the query, transaction queries it too
+func TestOpenFunc(t *testing.T) {
+	errNoTable := errors.New("table doesn't exist")
+	db := OpenFunc(func(query string, args []driver.NamedValue) ([]ResultSet, error) {
+		if query != "SELECT ?" || len(args) != 1 || args[0].Value != int64(1) {
+			return nil, errNoTable
+		}
+		return []ResultSet{{Columns: []Column{{Name: "v", DatabaseType: "BIGINT"}}, Rows: [][]any{{args[0].Value}}}}, nil
+	})
+	defer db.Close()
+	var v int64
+	if err := db.QueryRow("SELECT ?", 1).Scan(&v); err != nil || v != 1 {
+		t.Errorf("expect 1, got %d %v", v, err)
+	}
+	if _, err := db.Query("SELECT 1 FROM missing"); !errors.Is(err, errNoTable) {
+		t.Errorf("expect query error, got %v", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("db.Begin() failed: %v", err)
+	}
+	defer tx.Rollback()
+	if err = tx.QueryRow("SELECT ?", 1).Scan(&v); err != nil || v != 1 {
+		t.Errorf("tx expect 1, got %d %v", v, err)
+	}
+}
@@ -0,0 +1,69 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errNestedKeys rows of flat keys can't follow WithNestedKeys
+var errNestedKeys = errors.New("WithNestedKeys is supported by mapped rows only, like ScanMapped, GroupMapped and Scanner.MappedRow")
+
+// resolveNestedPaths split keys by separator into paths of nested maps, like user.name into [user name]
+// error when a key is both value and object like user and user.name, or has empty part like user..name
+func resolveNestedPaths(keys []string, separator string) ([][]string, error) {
+	paths := make([][]string, len(keys))
+	// kinds of path prefixes seen, true means object, false means value
+	objects := make(map[string]bool)
+	for i, key := range keys {
+		path := strings.Split(key, separator)
+		for _, part := range path {
+			if part == "" {
+				return nil, fmt.Errorf("nested key %s has empty part", key)
+			}
+		}
+		for depth := 1; depth <= len(path); depth++ {
+			prefix := strings.Join(path[:depth], separator)
+			isObject := depth < len(path)
+			if seenObject, ok := objects[prefix]; ok && (!seenObject || !isObject) {
+				return nil, fmt.Errorf("nested key %s conflicts with column %s", key, prefix)
+			}
+			objects[prefix] = isObject
+		}
+		paths[i] = path
+	}
+	return paths, nil
+}
+
+// nestValues put values into nested maps following paths
+func nestValues(paths [][]string, values []any) map[string]any {
+	root := make(map[string]any)
+	for i, path := range paths {
+		node := root
+		for _, part := range path[:len(path)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = make(map[string]any)
+				node[part] = child
+			}
+			node = child
+		}
+		node[path[len(path)-1]] = values[i]
+	}
+	return root
+}
+
+// flatErr error resolving keys of rows keyed flat like OrderedRow and ScanStructs, which can't nest keys
+func (s *Scanner) flatErr() error {
+	if s.duplicateErr != nil {
+		return s.duplicateErr
+	}
+	if s.cfg.nestSeparator != "" {
+		return errNestedKeys
+	}
+	return nil
+}
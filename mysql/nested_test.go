@@ -0,0 +1,100 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestNestedKeys
+// sql: SELECT id, name AS `user.name`, email AS `user.email`, total AS `order.total` ... row: [1, 'mysql', 'a@b.c', 9.50]
+// expect result: [{"id":1,"order":{"total":9.50},"user":{"email":"a@b.c","name":"mysql"}}]
+func TestNestedKeys(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "user.name", DatabaseType: "VARCHAR"},
+			{Name: "user.email", DatabaseType: "VARCHAR"},
+			{Name: "order.total", DatabaseType: "DECIMAL"},
+		},
+		Rows: [][]any{{"1", "mysql", "a@b.c", "9.50"}},
+	})
+	mappedRows, err := ScanMapped(rows, WithNestedKeys("."))
+	if err != nil {
+		t.Fatalf("ScanMapped() failed: %v", err)
+	}
+	bytes, err := json.Marshal(mappedRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const nestedJson = "[{\"id\":1,\"order\":{\"total\":9.50},\"user\":{\"email\":\"a@b.c\",\"name\":\"mysql\"}}]"
+	if string(bytes) != nestedJson {
+		t.Errorf("expect %s, got %s", nestedJson, bytes)
+	}
+}
+
+// TestNestedKeysConflict
+// column names conflict as value and object, or have empty part
+func TestNestedKeysConflict(t *testing.T) {
+	tests := [][]string{
+		{"user", "user__name"},
+		{"user__name", "user"},
+		{"user__name__first", "user__name"},
+		{"user____name"},
+		{"__name"},
+	}
+	for _, keys := range tests {
+		if _, err := resolveNestedPaths(keys, "__"); err == nil {
+			t.Errorf("resolveNestedPaths(%v) expect error", keys)
+		}
+	}
+	paths, err := resolveNestedPaths([]string{"a__b__c", "a__b__d", "a__e", "f"}, "__")
+	if err != nil || len(paths) != 4 || len(paths[0]) != 3 {
+		t.Errorf("resolveNestedPaths() got %v %v", paths, err)
+	}
+}
+
+// TestNestedKeysFlatRows
+// sql: SELECT a AS `u.a`, b AS `u.b` ... row: [1, 2]
+// expect result: ordered rows, structs and ScanInto fail with WithNestedKeys instead of returning flat keys
+func TestNestedKeysFlatRows(t *testing.T) {
+	queryRows := func() *sql.Rows {
+		return queryFake(t, mysqltest.ResultSet{
+			Columns: []mysqltest.Column{
+				{Name: "u.a", DatabaseType: "BIGINT"},
+				{Name: "u.b", DatabaseType: "BIGINT"},
+			},
+			Rows: [][]any{{"1", "2"}},
+		})
+	}
+	if _, err := ScanOrderedRows(queryRows(), WithNestedKeys(".")); !errors.Is(err, errNestedKeys) {
+		t.Errorf("ScanOrderedRows() expect nested keys error, got %v", err)
+	}
+	if _, err := ScanStructs(queryRows(), WithNestedKeys(".")); !errors.Is(err, errNestedKeys) {
+		t.Errorf("ScanStructs() expect nested keys error, got %v", err)
+	}
+	var dest []struct{ A int64 }
+	if err := ScanInto(queryRows(), &dest, WithNestedKeys(".")); !errors.Is(err, errNestedKeys) {
+		t.Errorf("ScanInto() expect nested keys error, got %v", err)
+	}
+	scanner, err := NewScanner(queryRows(), WithNestedKeys("."))
+	if err != nil {
+		t.Fatalf("NewScanner() failed: %v", err)
+	}
+	if !scanner.Next() {
+		t.Fatalf("Next() failed: %v", scanner.Err())
+	}
+	if _, err = scanner.OrderedRow(); !errors.Is(err, errNestedKeys) {
+		t.Errorf("OrderedRow() expect nested keys error, got %v", err)
+	}
+	if mappedRow, err := scanner.MappedRow(); err != nil || mappedRow["u"] == nil {
+		t.Errorf("MappedRow() expect nested row, got %v %v", mappedRow, err)
+	}
+}
@@ -0,0 +1,297 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimestampMode how TIMESTAMP values are converted
+type TimestampMode int
+
+const (
+	// TimestampAsUnix convert to unix timestamp in seconds, int64, fractional seconds are truncated
+	TimestampAsUnix TimestampMode = iota
+	// TimestampAsTime convert to time.Time like DATETIME
+	TimestampAsTime
+	// TimestampAsUnixMilli convert to unix timestamp in milliseconds, int64, suit for TIMESTAMP(3)
+	TimestampAsUnixMilli
+	// TimestampAsUnixMicro convert to unix timestamp in microseconds, int64, suit for TIMESTAMP(6)
+	TimestampAsUnixMicro
+)
+
+// JSONMode how JSON values are converted
+type JSONMode int
+
+const (
+	// JSONAsParsed unmarshal into any, marshaled back as json object/array
+	JSONAsParsed JSONMode = iota
+	// JSONAsString keep raw json text, marshaled as json string
+	JSONAsString
+)
+
+// ZeroDatePolicy how zero date like 0000-00-00 and partial zero date like 2024-01-00 are converted
+// DATE, DATETIME and TIMESTAMP columns may contain them when sql_mode allows
+type ZeroDatePolicy int
+
+const (
+	// ZeroDateAsError fail the scan, time.Parse rejects zero date
+	ZeroDateAsError ZeroDatePolicy = iota
+	// ZeroDateAsNil convert to nil like NULL
+	ZeroDateAsNil
+	// ZeroDateAsZeroTime convert to time.Time{}, or 0 when TIMESTAMP is converted to number
+	ZeroDateAsZeroTime
+	// ZeroDateAsString keep original text like 0000-00-00 00:00:00
+	ZeroDateAsString
+)
+
+// ValueMode shape of converted values
+type ValueMode int
+
+const (
+	// ValueAsPlain plain values like int64, float64, string, time.Time, any of parsed JSON, untyped nil for NULL
+	ValueAsPlain ValueMode = iota
+	// ValueAsPointer shapes of built-in converters before ValueMode introduced, for code not migrated yet
+	// BIGINT, INT, SMALLINT, TINYINT, YEAR are *int64, DOUBLE, FLOAT and DECIMAL are *float64, JSON is *any or *string
+	// DATETIME, DATE are time.Time and TIMESTAMP follows WithTimestampAs, plain as before
+	// other types had no converter, so they are raw *string like columns without converter, nil *string for NULL
+	// NULL of the other converted types is untyped nil, converters without Configure keep their values as is
+	// the deprecated scan functions convert by scan type of driver as before, see SimplySQLTypeConverters
+	ValueAsPointer
+)
+
+// config scan behaviors collected from options
+type config struct {
+	registry       *Registry
+	serverLocation *time.Location
+	location       *time.Location
+	timestampAs    TimestampMode
+	jsonMode       JSONMode
+	zeroDate       ZeroDatePolicy
+	binaryEncoding BinaryEncoding
+	ctx            context.Context
+	maxRows        int
+	maxBytes       int64
+	duplicates     DuplicateStrategy
+	nestSeparator  string
+	nullGroupKey   string
+	// scanTypeConverters convert by scan type of driver under ValueAsPointer, like the deprecated scan functions did
+	scanTypeConverters bool
+	strictness         Strictness
+	valueMode          ValueMode
+	lenient            bool
+	fallback           FallbackMode
+	report             *ConversionReport
+}
+
+// Option change scan behavior, see With* functions
+type Option func(*config)
+
+// newConfig default config with options applied, nil registry means DefaultRegistry
+func newConfig(opts ...Option) *config {
+	cfg := &config{
+		serverLocation: time.UTC,
+		location:       time.Local,
+		timestampAs:    TimestampAsUnix,
+		jsonMode:       JSONAsParsed,
+		zeroDate:       ZeroDateAsError,
+		nullGroupKey:   "null",
+		valueMode:      ValueAsPlain,
+		ctx:            context.Background(),
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// converterOptions options of scan passed to Converter.Configure
+func (cfg *config) converterOptions() ConverterOptions {
+	return ConverterOptions{
+		ServerLocation: cfg.serverLocation,
+		Location:       cfg.location,
+		TimestampAs:    cfg.timestampAs,
+		JSONMode:       cfg.jsonMode,
+		ZeroDate:       cfg.zeroDate,
+		BinaryEncoding: cfg.binaryEncoding,
+		ValueMode:      cfg.valueMode,
+	}
+}
+
+// registryOrDefault registry of scan, DefaultRegistry when not set
+func (cfg *config) registryOrDefault() *Registry {
+	if cfg.registry == nil {
+		return DefaultRegistry
+	}
+	return cfg.registry
+}
+
+// WithRegistry convert values with converters of given registry instead of DefaultRegistry
+func WithRegistry(registry *Registry) Option {
+	return func(cfg *config) {
+		cfg.registry = registry
+	}
+}
+
+// WithServerLocation time zone of mysql session, DATETIME and TIMESTAMP text are parsed in it, default time.UTC
+// DATETIME has no zone, its wall clock is kept when server location and output location are the same
+// DATE keeps its calendar day whatever the locations are
+//
+//	Scan(rows, WithServerLocation(shanghai), WithLocation(shanghai))
+func WithServerLocation(location *time.Location) Option {
+	return func(cfg *config) {
+		if location != nil {
+			cfg.serverLocation = location
+		}
+	}
+}
+
+// WithLocation output location of DATETIME, DATE and TIMESTAMP converted into time values, default time.Local
+// DATE becomes midnight of its calendar day in it
+// results depend on TZ of the host with default, set it explicitly for reproducible results
+func WithLocation(location *time.Location) Option {
+	return func(cfg *config) {
+		if location != nil {
+			cfg.location = location
+		}
+	}
+}
+
+// WithTimestampAs how TIMESTAMP values are converted, default TimestampAsUnix
+func WithTimestampAs(mode TimestampMode) Option {
+	return func(cfg *config) {
+		cfg.timestampAs = mode
+	}
+}
+
+// WithJSONMode how JSON values are converted, default JSONAsParsed
+func WithJSONMode(mode JSONMode) Option {
+	return func(cfg *config) {
+		cfg.jsonMode = mode
+	}
+}
+
+// WithZeroDate how zero and partial zero dates of DATE, DATETIME and TIMESTAMP are converted, default ZeroDateAsError
+func WithZeroDate(policy ZeroDatePolicy) Option {
+	return func(cfg *config) {
+		cfg.zeroDate = policy
+	}
+}
+
+// WithBinaryEncoding how values of binary columns are marshaled into json, default BinaryAsBase64
+// followed by built-in converters, not by converters of BinaryConverters
+func WithBinaryEncoding(encoding BinaryEncoding) Option {
+	return func(cfg *config) {
+		cfg.binaryEncoding = encoding
+	}
+}
+
+// WithMaxRows stop scan with TruncatedError when result set has more than n rows, rows scanned are kept, 0 means no limit
+func WithMaxRows(n int) Option {
+	return func(cfg *config) {
+		cfg.maxRows = n
+	}
+}
+
+// WithMaxBytes stop scan with TruncatedError when raw values of rows exceed n bytes in total, rows scanned are kept, 0 means no limit
+func WithMaxBytes(n int64) Option {
+	return func(cfg *config) {
+		cfg.maxBytes = n
+	}
+}
+
+// WithDuplicateColumns how duplicate column names are keyed in mapped and ordered rows, default DuplicateAsError
+func WithDuplicateColumns(strategy DuplicateStrategy) Option {
+	return func(cfg *config) {
+		cfg.duplicates = strategy
+	}
+}
+
+// WithNestedKeys split column names by separator into nested objects of mapped rows
+// columns user.name, user.email become {"user": {"name": ..., "email": ...}} with separator "."
+// mapped scan fails when a column is both value and object, like user and user.name
+// supported by mapped rows of ScanMapped, GroupMapped, result sets, queries and Scanner.MappedRow
+// ScanOrderedRows, ScanStructs, ScanInto and Scanner.OrderedRow fail with it, their keys are flat
+func WithNestedKeys(separator string) Option {
+	return func(cfg *config) {
+		cfg.nestSeparator = separator
+	}
+}
+
+// WithNullGroupKey key of NULL group of GroupMappedByString, default "null"
+// the scan fails when a value is formatted as the same key, pick a key the column can't hold
+func WithNullGroupKey(key string) Option {
+	return func(cfg *config) {
+		cfg.nullGroupKey = key
+	}
+}
+
+// WithStrictness report unmapped columns and fields of ScanInto as error, default StrictNone
+func WithStrictness(strictness Strictness) Option {
+	return func(cfg *config) {
+		cfg.strictness = strictness
+	}
+}
+
+// WithValueMode shape of converted values, default ValueAsPlain
+func WithValueMode(mode ValueMode) Option {
+	return func(cfg *config) {
+		cfg.valueMode = mode
+	}
+}
+
+// withContext check ctx between rows, set by *Context functions
+func withContext(ctx context.Context) Option {
+	return func(cfg *config) {
+		if ctx != nil {
+			cfg.ctx = ctx
+		}
+	}
+}
+
+// scanTypeErr error of options converters of scan type can't follow, these convert by scan type of driver only
+// rows limits, context, keys of mapped rows and WithLenient still apply
+func (cfg *config) scanTypeErr() error {
+	var unsupported []string
+	if cfg.registry != nil {
+		unsupported = append(unsupported, "WithRegistry")
+	}
+	if cfg.serverLocation != time.UTC {
+		unsupported = append(unsupported, "WithServerLocation")
+	}
+	if cfg.location != time.UTC {
+		unsupported = append(unsupported, "WithLocation")
+	}
+	if cfg.timestampAs != TimestampAsTime {
+		unsupported = append(unsupported, "WithTimestampAs")
+	}
+	if cfg.jsonMode != JSONAsString {
+		unsupported = append(unsupported, "WithJSONMode")
+	}
+	if cfg.zeroDate != ZeroDateAsError {
+		unsupported = append(unsupported, "WithZeroDate")
+	}
+	if cfg.binaryEncoding != BinaryAsBase64 {
+		unsupported = append(unsupported, "WithBinaryEncoding")
+	}
+	if len(unsupported) > 0 {
+		return fmt.Errorf("%s not supported with ValueAsPointer of deprecated scan functions, values are converted by scan type of driver", strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
+// deprecatedOptions behaviors of the deprecated scan functions
+// utc time, TIMESTAMP as time, JSON as string, converters of scan type under ValueAsPointer
+var deprecatedOptions = []Option{
+	WithLocation(time.UTC),
+	WithTimestampAs(TimestampAsTime),
+	WithJSONMode(JSONAsString),
+	func(cfg *config) {
+		cfg.scanTypeConverters = true
+	},
+}
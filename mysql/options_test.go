@@ -0,0 +1,160 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// convertWithOptions scan raw value of a mysqlType column from fake driver under given options, marshal result as json
+// nil in is NULL
+func convertWithOptions(t *testing.T, mysqlType string, in *string, opts ...Option) (string, error) {
+	t.Helper()
+	if _, ok := newConfig(opts...).registryOrDefault().lookup(mysqlType); !ok {
+		t.Fatalf("no converter for %s", mysqlType)
+	}
+	var raw any
+	if in != nil {
+		raw = *in
+	}
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "v", DatabaseType: mysqlType}},
+		Rows:    [][]any{{raw}},
+	})
+	allRows, err := Scan(rows, opts...)
+	if err != nil {
+		return "", err
+	}
+	bytes, err := json.Marshal(allRows[0][0])
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	return string(bytes), nil
+}
+
+// TestScanOptions
+// TIMESTAMP, DATETIME and JSON values converted under location, timestamp and json options
+func TestScanOptions(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	newYork := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		name      string
+		mysqlType string
+		in        string
+		opts      []Option
+		expect    string
+	}{
+		{"timestamp as unix", "TIMESTAMP", "2024-01-01 10:00:00", nil, "1704103200"},
+		{"timestamp as time", "TIMESTAMP", "2024-01-01 10:00:00", []Option{WithTimestampAs(TimestampAsTime), WithLocation(time.UTC)}, "\"2024-01-01T10:00:00Z\""},
+		{"datetime in location", "DATETIME", "2024-01-01 10:00:00", []Option{WithLocation(shanghai)}, "\"2024-01-01T18:00:00+08:00\""},
+		{"date in utc", "DATE", "2024-01-01", []Option{WithLocation(time.UTC)}, "\"2024-01-01T00:00:00Z\""},
+		{"json parsed", "JSON", "{\"a\": 1}", nil, "{\"a\":1}"},
+		{"json as string", "JSON", "{\"a\": 1}", []Option{WithJSONMode(JSONAsString)}, "\"{\\\"a\\\": 1}\""},
+		{"datetime in server location", "DATETIME", "2024-01-01 10:00:00", []Option{WithServerLocation(shanghai), WithLocation(shanghai)}, "\"2024-01-01T10:00:00+08:00\""},
+		{"datetime to utc", "DATETIME", "2024-01-01 10:00:00", []Option{WithServerLocation(shanghai), WithLocation(time.UTC)}, "\"2024-01-01T02:00:00Z\""},
+		{"timestamp in server location", "TIMESTAMP", "2024-01-01 18:00:00", []Option{WithServerLocation(shanghai)}, "1704103200"},
+		{"date in server location", "DATE", "2024-01-01", []Option{WithServerLocation(shanghai), WithLocation(shanghai)}, "\"2024-01-01T00:00:00+08:00\""},
+		{"date keeps calendar day", "DATE", "2024-01-01", []Option{WithServerLocation(shanghai), WithLocation(newYork)}, "\"2024-01-01T00:00:00-05:00\""},
+		{"date keeps calendar day from utc", "DATE", "2024-01-01", []Option{WithLocation(newYork)}, "\"2024-01-01T00:00:00-05:00\""},
+		{"utc text ignore server location", "DATETIME", "2024-01-01T10:00:00Z", []Option{WithServerLocation(shanghai), WithLocation(time.UTC)}, "\"2024-01-01T10:00:00Z\""},
+		{"datetime(6)", "DATETIME", "2024-01-01 10:00:00.123456", []Option{WithLocation(time.UTC)}, "\"2024-01-01T10:00:00.123456Z\""},
+		{"datetime(3) utc text", "DATETIME", "2024-01-01T10:00:00.123Z", []Option{WithLocation(time.UTC)}, "\"2024-01-01T10:00:00.123Z\""},
+		{"timestamp(6) as time", "TIMESTAMP", "2024-01-01 10:00:00.000001", []Option{WithTimestampAs(TimestampAsTime), WithLocation(time.UTC)}, "\"2024-01-01T10:00:00.000001Z\""},
+		{"timestamp(6) as unix", "TIMESTAMP", "2024-01-01 10:00:00.999999", nil, "1704103200"},
+		{"timestamp(3) as unix milli", "TIMESTAMP", "2024-01-01 10:00:00.123", []Option{WithTimestampAs(TimestampAsUnixMilli)}, "1704103200123"},
+		{"timestamp(6) as unix micro", "TIMESTAMP", "2024-01-01 10:00:00.123456", []Option{WithTimestampAs(TimestampAsUnixMicro)}, "1704103200123456"},
+		{"time(6)", "TIME", "-10:00:00.000001", nil, "\"-10:00:00.000001\""},
+		{"deprecated", "TIMESTAMP", "2024-01-01 10:00:00", deprecatedOptions, "\"2024-01-01T10:00:00Z\""},
+	}
+	for _, test := range tests {
+		in := test.in
+		got, err := convertWithOptions(t, test.mysqlType, &in, test.opts...)
+		if err != nil {
+			t.Errorf("%s: convert failed: %v", test.name, err)
+			continue
+		}
+		if got != test.expect {
+			t.Errorf("%s: expect %s, got %s", test.name, test.expect, got)
+		}
+	}
+}
+
+// TestScanWithRegistryOption
+// built-in converter registered back after Lookup keeps following options by Configure, ReplaceFunc without Configure ignores them
+func TestScanWithRegistryOption(t *testing.T) {
+	registry := DefaultRegistry.Clone()
+	converter, _ := registry.Lookup("JSON")
+	converter.Name = "handle JSON again"
+	if err := registry.Override(converter); err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	in := "[1]"
+	got, err := convertWithOptions(t, "JSON", &in, WithRegistry(registry), WithJSONMode(JSONAsString))
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	if got != "\"[1]\"" {
+		t.Errorf("expect \"[1]\", got %s", got)
+	}
+
+	replaceFunc := converter.ReplaceFunc
+	converter.ReplaceFunc = func(in *string) (any, error) {
+		return replaceFunc(in)
+	}
+	converter.Configure = nil
+	if err = registry.Override(converter); err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	got, err = convertWithOptions(t, "JSON", &in, WithRegistry(registry), WithJSONMode(JSONAsString))
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	if got != "[1]" {
+		t.Errorf("expect [1], got %s", got)
+	}
+}
+
+// TestCustomConverterConfigure
+// custom DATETIME converter formatting text in output location, Configure given location of WithLocation
+// expect result: "2024-01-01T18:00:00+08:00" with WithLocation(UTC+8)
+func TestCustomConverterConfigure(t *testing.T) {
+	registry := DefaultRegistry.Clone()
+	err := registry.Override(Converter{
+		Name:      "handle DATETIME as RFC3339",
+		MySQLType: "DATETIME",
+		Configure: func(opts ConverterOptions) (func(*string) (any, error), reflect.Type) {
+			return func(in *string) (any, error) {
+				if in == nil {
+					return nil, nil
+				}
+				v, err := time.ParseInLocation(time.DateTime, *in, opts.ServerLocation)
+				if err != nil {
+					return nil, err
+				}
+				return v.In(opts.Location).Format(time.RFC3339), nil
+			}, reflect.TypeOf("")
+		},
+	})
+	if err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	converter, _ := registry.Lookup("DATETIME")
+	if converter.ReplaceFunc == nil || converter.ValueType != reflect.TypeOf("") {
+		t.Errorf("expect ReplaceFunc and ValueType set from Configure, got %v", converter.ValueType)
+	}
+	in := "2024-01-01 10:00:00"
+	got, err := convertWithOptions(t, "DATETIME", &in, WithRegistry(registry), WithLocation(time.FixedZone("UTC+8", 8*3600)))
+	if err != nil {
+		t.Fatalf("convert failed: %v", err)
+	}
+	if got != "\"2024-01-01T18:00:00+08:00\"" {
+		t.Errorf("expect \"2024-01-01T18:00:00+08:00\", got %s", got)
+	}
+}
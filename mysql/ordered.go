@@ -0,0 +1,134 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+)
+
+// OrderedRow converted values of a row keyed by column name, keeping columns in select order
+// marshaled as json object whose keys follow select order instead of alphabetical order like map
+type OrderedRow struct {
+	keys   []string
+	values []any
+	index  map[string]int
+}
+
+// newOrderedRow create ordered row, keys are shared between rows of the same result set
+func newOrderedRow(keys []string, index map[string]int, values []any) *OrderedRow {
+	return &OrderedRow{keys: keys, values: values, index: index}
+}
+
+// Get value of column, false when column not exist
+func (r *OrderedRow) Get(key string) (any, bool) {
+	i, ok := r.index[key]
+	if !ok {
+		return nil, false
+	}
+	return r.values[i], true
+}
+
+// Keys column names in select order
+func (r *OrderedRow) Keys() []string {
+	return r.keys
+}
+
+// Values converted values in select order
+func (r *OrderedRow) Values() []any {
+	return r.values
+}
+
+// Len count of columns
+func (r *OrderedRow) Len() int {
+	return len(r.keys)
+}
+
+// Range call f with each column and value in select order, stop when f returns false
+func (r *OrderedRow) Range(f func(key string, value any) bool) {
+	for i, key := range r.keys {
+		if !f(key, r.values[i]) {
+			return
+		}
+	}
+}
+
+// Map convert to map, order is lost
+func (r *OrderedRow) Map() map[string]any {
+	m := make(map[string]any, len(r.keys))
+	for i, key := range r.keys {
+		m[key] = r.values[i]
+	}
+	return m
+}
+
+// MarshalJSON marshal as json object with keys in select order
+func (r *OrderedRow) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range r.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(keyBytes)
+		buf.WriteByte(':')
+		valueBytes, err := json.Marshal(r.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(valueBytes)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// OrderedRow converted values of current row keyed by column name in select order
+// duplicate column names are keyed following WithDuplicateColumns, error by default
+// keys are not nested, error with WithNestedKeys
+func (s *Scanner) OrderedRow() (*OrderedRow, error) {
+	if err := s.flatErr(); err != nil {
+		return nil, err
+	}
+	if s.keyIndex == nil {
+		s.keyIndex = make(map[string]int, len(s.keys.names))
+		for i, key := range s.keys.names {
+			s.keyIndex[key] = i
+		}
+	}
+	return newOrderedRow(s.keys.names, s.keyIndex, s.keys.keyedValues(s.row)), nil
+}
+
+// ScanOrderedRows same as ScanMapped, but each row keeps select order of columns, also in marshaled json
+// keys are not nested, error with WithNestedKeys
+// return format likes: [{'col1': number, 'col2': 'string',...}...] with keys in select order
+func ScanOrderedRows(rows *sql.Rows, opts ...Option) ([]*OrderedRow, error) {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = scanner.flatErr(); err != nil {
+		return nil, err
+	}
+	var allRows []*OrderedRow
+	for scanner.Next() {
+		orderedRow, err := scanner.OrderedRow()
+		if err != nil {
+			return nil, err
+		}
+		allRows = append(allRows, orderedRow)
+	}
+	if err = scanner.Err(); err != nil {
+		if isPartial(err) {
+			return allRows, err
+		}
+		return nil, err
+	}
+	return allRows, nil
+}
@@ -0,0 +1,53 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestScanOrderedRows
+// sql: SELECT name, id, content FROM t1
+// expect result keys in select order: [{"name":"mysql","id":1,"content":{"b":1,"a":2}}]
+func TestScanOrderedRows(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "name", DatabaseType: "VARCHAR"}, {Name: "id", DatabaseType: "BIGINT"}, {Name: "content", DatabaseType: "JSON"}},
+		Rows:    [][]any{{"mysql", "1", "{\"b\": 1, \"a\": 2}"}},
+	})
+	orderedRows, err := ScanOrderedRows(rows)
+	if err != nil {
+		t.Fatalf("ScanOrderedRows() failed: %v", err)
+	}
+	bytes, err := json.Marshal(orderedRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	// keys of parsed JSON column are still sorted by encoding/json
+	const orderedJson = "[{\"name\":\"mysql\",\"id\":1,\"content\":{\"a\":2,\"b\":1}}]"
+	if string(bytes) != orderedJson {
+		t.Errorf("expect %s, got %s", orderedJson, bytes)
+	}
+	row := orderedRows[0]
+	if v, ok := row.Get("name"); !ok || v != "mysql" {
+		t.Errorf("Get(name) got %v %v", v, ok)
+	}
+	if _, ok := row.Get("missing"); ok {
+		t.Errorf("Get(missing) expect false")
+	}
+	var keys []string
+	row.Range(func(key string, value any) bool {
+		keys = append(keys, key)
+		return len(keys) < 2
+	})
+	if len(keys) != 2 || keys[0] != "name" || keys[1] != "id" {
+		t.Errorf("Range() expect [name id], got %v", keys)
+	}
+	if len(row.Map()) != 3 {
+		t.Errorf("Map() expect 3 keys, got %v", row.Map())
+	}
+}
@@ -0,0 +1,66 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Querier run query returning rows, satisfied by *sql.DB, *sql.Conn and *sql.Tx
+type Querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Conn)(nil)
+	_ Querier = (*sql.Tx)(nil)
+)
+
+// QueryRows run query and scan rows like ScanAnonymousRows, rows are always closed
+// return format likes: [[number, 'string', '0000-00-00T00:00:00Z',...]...]
+func QueryRows(ctx context.Context, q Querier, query string, args ...any) ([][]any, error) {
+	return QueryRowsWith(ctx, q, nil, query, args...)
+}
+
+// QueryRowsWith same as QueryRows, but scan rows under given options like Scan
+// rows scanned are returned along with *TruncatedError when stopped by WithMaxRows or WithMaxBytes
+//
+//	allValues, err := QueryRowsWith(ctx, db, []Option{WithMaxRows(1000)}, "SELECT * FROM t1")
+func QueryRowsWith(ctx context.Context, q Querier, opts []Option, query string, args ...any) ([][]any, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed, %w", err)
+	}
+	allValues, err := ScanContext(ctx, rows, opts...)
+	return allValues, closeRows(rows, err)
+}
+
+// QueryMapped run query and scan rows like ScanAnonymousMappedRows, rows are always closed
+// return format likes: [{'col1': number, 'col2': 'string', 'col3': '0000-00-00T00:00:00±0:00',...}...]
+func QueryMapped(ctx context.Context, q Querier, query string, args ...any) ([]map[string]any, error) {
+	return QueryMappedWith(ctx, q, nil, query, args...)
+}
+
+// QueryMappedWith same as QueryMapped, but scan rows under given options like ScanMapped
+// rows scanned are returned along with *TruncatedError when stopped by WithMaxRows or WithMaxBytes
+func QueryMappedWith(ctx context.Context, q Querier, opts []Option, query string, args ...any) ([]map[string]any, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query failed, %w", err)
+	}
+	mappedRows, err := ScanMappedContext(ctx, rows, opts...)
+	return mappedRows, closeRows(rows, err)
+}
+
+// closeRows close rows, keep scan error first
+func closeRows(rows *sql.Rows, scanErr error) error {
+	if err := rows.Close(); err != nil && scanErr == nil {
+		return fmt.Errorf("close rows failed, %w", err)
+	}
+	return scanErr
+}
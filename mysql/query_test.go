@@ -0,0 +1,108 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+var errNoSuchTable = errors.New("table doesn't exist")
+
+// queryT1Rows serve t1 rows [1, 'mysql'], [2, 'mariadb'] to queries of TestQueryMapped
+func queryT1Rows(query string, args []driver.NamedValue) ([]mysqltest.ResultSet, error) {
+	resultSet := mysqltest.ResultSet{
+		Columns: t1.Columns,
+		Rows:    [][]any{{int64(1), "mysql"}, {int64(2), "mariadb"}},
+	}
+	switch query {
+	case "SELECT * FROM t1 WHERE id = ?":
+		if len(args) != 1 || args[0].Value != int64(1) {
+			return nil, errors.New("expect id 1")
+		}
+		resultSet.Rows = resultSet.Rows[:1]
+	case "SELECT * FROM t1 ORDER BY id":
+	default:
+		return nil, errNoSuchTable
+	}
+	return []mysqltest.ResultSet{resultSet}, nil
+}
+
+// TestQueryMapped
+// query t1 row: [1, 'mysql'] through *sql.DB, *sql.Conn and *sql.Tx
+// expect result: [{"id":1,"name":"mysql"}]
+func TestQueryMapped(t *testing.T) {
+	ctx := context.Background()
+	db := mysqltest.OpenFunc(queryT1Rows)
+	t.Cleanup(func() { _ = db.Close() })
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("db.Conn() failed: %v", err)
+	}
+	mappedRows, err := QueryMapped(ctx, conn, "SELECT * FROM t1 WHERE id = ?", 1)
+	_ = conn.Close()
+	assertMappedJson(t, "conn", mappedRows, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("db.BeginTx() failed: %v", err)
+	}
+	mappedRows, err = QueryMapped(ctx, tx, "SELECT * FROM t1 WHERE id = ?", 1)
+	_ = tx.Rollback()
+	assertMappedJson(t, "tx", mappedRows, err)
+
+	mappedRows, err = QueryMapped(ctx, db, "SELECT * FROM t1 WHERE id = ?", 1)
+	assertMappedJson(t, "db", mappedRows, err)
+
+	allValues, err := QueryRows(ctx, db, "SELECT * FROM t1 ORDER BY id")
+	if err != nil || len(allValues) != 2 {
+		t.Errorf("QueryRows() expect 2 rows, got %v %v", allValues, err)
+	}
+	if _, err = QueryRows(ctx, db, "SELECT * FROM t_missing"); !errors.Is(err, errNoSuchTable) {
+		t.Errorf("QueryRows() of missing table expect error")
+	}
+}
+
+// TestQueryWithOptions
+// query t1 rows: [1, 'mysql'], [2, 'mariadb'] with options
+// expect result: first row along with *TruncatedError of WithMaxRows(1), and rows converted with registry of WithRegistry
+func TestQueryWithOptions(t *testing.T) {
+	ctx := context.Background()
+	db := mysqltest.OpenFunc(queryT1Rows)
+	t.Cleanup(func() { _ = db.Close() })
+
+	allValues, err := QueryRowsWith(ctx, db, []Option{WithMaxRows(1)}, "SELECT * FROM t1 ORDER BY id")
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) || len(allValues) != 1 {
+		t.Errorf("QueryRowsWith() expect 1 row and truncated error, got %v %v", allValues, err)
+	}
+	mappedRows, err := QueryMappedWith(ctx, db, []Option{WithMaxRows(1)}, "SELECT * FROM t1 ORDER BY id")
+	if !errors.As(err, &truncated) || len(mappedRows) != 1 {
+		t.Errorf("QueryMappedWith() expect 1 row and truncated error, got %v %v", mappedRows, err)
+	}
+	mappedRows, err = QueryMappedWith(ctx, db, []Option{WithRegistry(NewDefaultRegistry())}, "SELECT * FROM t1 WHERE id = ?", 1)
+	assertMappedJson(t, "db with options", mappedRows, err)
+}
+
+func assertMappedJson(t *testing.T, querier string, mappedRows []map[string]any, err error) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s QueryMapped() failed: %v", querier, err)
+		return
+	}
+	bytes, err := json.Marshal(mappedRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const mappedJson = "[{\"id\":1,\"name\":\"mysql\"}]"
+	if string(bytes) != mappedJson {
+		t.Errorf("%s expect %s, got %s", querier, mappedJson, bytes)
+	}
+}
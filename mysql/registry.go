@@ -0,0 +1,167 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Registry converters keyed by database type name, safe for concurrent use
+// register custom converters (e.g. DECIMAL into decimal library values) without forking the built-in ones
+type Registry struct {
+	mu         sync.RWMutex
+	converters map[string]Converter
+}
+
+// DefaultRegistry registry used by the scan functions without explicit registry
+// holds the built-in mysql converters, changes on it affect every later default scan
+var DefaultRegistry = NewDefaultRegistry()
+
+// NewRegistry create registry with given converters, later converters override former ones with the same type
+// converter without MySQLType, or without both ReplaceFunc and Configure, given error like Register
+func NewRegistry(converters ...Converter) (*Registry, error) {
+	for _, converter := range converters {
+		if err := validateConverter(converter); err != nil {
+			return nil, err
+		}
+	}
+	return newRegistry(converters...), nil
+}
+
+// newRegistry create registry with given valid converters
+func newRegistry(converters ...Converter) *Registry {
+	r := &Registry{converters: make(map[string]Converter, len(converters))}
+	for _, converter := range converters {
+		r.converters[normalizeTypeName(converter.MySQLType)] = converter.configured()
+	}
+	return r
+}
+
+// NewDefaultRegistry create a fresh registry with the built-in mysql converters
+func NewDefaultRegistry() *Registry {
+	return newRegistry(mysqlTypeConverters...)
+}
+
+// Register add converter for a database type not registered yet
+func (r *Registry) Register(converter Converter) error {
+	if err := validateConverter(converter); err != nil {
+		return err
+	}
+	typeName := normalizeTypeName(converter.MySQLType)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.converters[typeName]; ok {
+		return fmt.Errorf("converter for type %s already registered", typeName)
+	}
+	r.converters[typeName] = converter.configured()
+	return nil
+}
+
+// Override add or replace converters for database types, nothing changed when any converter is invalid
+func (r *Registry) Override(converters ...Converter) error {
+	for _, converter := range converters {
+		if err := validateConverter(converter); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, converter := range converters {
+		r.converters[normalizeTypeName(converter.MySQLType)] = converter.configured()
+	}
+	return nil
+}
+
+// Lookup get converter by database type name, name is case-insensitive
+// column definition style name like VARCHAR(18) falls back to converter of VARCHAR when not registered itself
+// ReplaceFunc of returned converter follows default options, scans use its Configure when not nil
+func (r *Registry) Lookup(mysqlType string) (Converter, bool) {
+	return r.lookup(mysqlType)
+}
+
+func (r *Registry) lookup(mysqlType string) (Converter, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	typeName := normalizeTypeName(mysqlType)
+	if converter, ok := r.converters[typeName]; ok {
+		return converter, ok
+	}
+	converter, ok := r.converters[stripTypeParams(typeName)]
+	return converter, ok
+}
+
+// Clone copy registry, changes on the copy don't affect the origin
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c := &Registry{converters: make(map[string]Converter, len(r.converters))}
+	for typeName, converter := range r.converters {
+		c.converters[typeName] = converter
+	}
+	return c
+}
+
+// replaceFuncs resolve converter of each column once under given scan options, nil means keep raw value
+func (r *Registry) replaceFuncs(cfg *config, mysqlTypes []string) []func(*string) (any, error) {
+	funcs := make([]func(*string) (any, error), len(mysqlTypes))
+	for i, mysqlType := range mysqlTypes {
+		if converter, ok := r.lookup(mysqlType); ok {
+			funcs[i] = converter.replaceFunc(cfg)
+		}
+	}
+	return funcs
+}
+
+// valueTypes resolve value type of each column under given scan options, raw string when no converter
+func (r *Registry) valueTypes(cfg *config, mysqlTypes []string) []reflect.Type {
+	types := make([]reflect.Type, len(mysqlTypes))
+	for i, mysqlType := range mysqlTypes {
+		if converter, ok := r.lookup(mysqlType); ok {
+			types[i] = converter.valueType(cfg)
+			continue
+		}
+		types[i] = reflect.TypeOf("")
+		if cfg.valueMode == ValueAsPointer {
+			types[i] = reflect.TypeOf((*string)(nil))
+		}
+	}
+	return types
+}
+
+func validateConverter(converter Converter) error {
+	if strings.TrimSpace(converter.MySQLType) == "" {
+		return errors.New("converter MySQLType is empty")
+	}
+	if converter.ReplaceFunc == nil && converter.Configure == nil {
+		return fmt.Errorf("converter for type %s has no ReplaceFunc or Configure", converter.MySQLType)
+	}
+	return nil
+}
+
+// normalizeTypeName upper case type name, column definition style like BIGINT UNSIGNED becomes UNSIGNED BIGINT as go-sql-driver reports
+func normalizeTypeName(mysqlType string) string {
+	typeName := strings.ToUpper(strings.TrimSpace(mysqlType))
+	if baseType, ok := strings.CutSuffix(typeName, " UNSIGNED"); ok {
+		return "UNSIGNED " + strings.TrimSpace(baseType)
+	}
+	return typeName
+}
+
+// stripTypeParams remove length, precision params of type name, DECIMAL(10,2) becomes DECIMAL
+func stripTypeParams(typeName string) string {
+	start := strings.IndexByte(typeName, '(')
+	if start < 0 {
+		return typeName
+	}
+	end := strings.IndexByte(typeName[start:], ')')
+	if end < 0 {
+		return typeName
+	}
+	return normalizeTypeName(typeName[:start] + typeName[start+end+1:])
+}
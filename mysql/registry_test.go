@@ -0,0 +1,102 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+var tinyIntBoolConverter = Converter{
+	Name:      "handle TINYINT as bool",
+	MySQLType: "TINYINT",
+	ReplaceFunc: func(in *string) (any, error) {
+		if in == nil {
+			return nil, nil
+		}
+		return *in != "0", nil
+	},
+}
+
+// TestRegistryRegister
+// register existed type given error, override replace it, lookup is case-insensitive
+func TestRegistryRegister(t *testing.T) {
+	registry := NewDefaultRegistry()
+	if err := registry.Register(tinyIntBoolConverter); err == nil {
+		t.Errorf("Register() existed TINYINT expect error")
+	}
+	if err := registry.Override(tinyIntBoolConverter); err != nil {
+		t.Errorf("Override() failed: %v", err)
+	}
+	converter, ok := registry.Lookup("tinyint")
+	if !ok || converter.Name != tinyIntBoolConverter.Name {
+		t.Errorf("Lookup() expect overridden converter, got %v", converter.Name)
+	}
+	if err := registry.Register(Converter{MySQLType: "GEOMETRY"}); err == nil {
+		t.Errorf("Register() converter without ReplaceFunc expect error")
+	}
+	if converter, _ = DefaultRegistry.Lookup("TINYINT"); converter.Name != "handle TINYINT" {
+		t.Errorf("DefaultRegistry changed by other registry")
+	}
+}
+
+// TestNewRegistryInvalidConverter
+// converter without ReplaceFunc or MySQLType given error instead of falling back to raw value silently
+func TestNewRegistryInvalidConverter(t *testing.T) {
+	if _, err := NewRegistry(tinyIntBoolConverter, Converter{MySQLType: "GEOMETRY"}); err == nil {
+		t.Errorf("NewRegistry() converter without ReplaceFunc expect error")
+	}
+	if _, err := NewRegistry(Converter{ReplaceFunc: tinyIntBoolConverter.ReplaceFunc}); err == nil {
+		t.Errorf("NewRegistry() converter without MySQLType expect error")
+	}
+}
+
+// TestRegistryClone
+// changes on cloned registry don't affect the origin
+func TestRegistryClone(t *testing.T) {
+	registry, err := NewRegistry()
+	if err != nil {
+		t.Fatalf("NewRegistry() failed: %v", err)
+	}
+	cloned := registry.Clone()
+	if err := cloned.Register(tinyIntBoolConverter); err != nil {
+		t.Errorf("Register() failed: %v", err)
+	}
+	if _, ok := registry.Lookup("TINYINT"); ok {
+		t.Errorf("origin registry changed by clone")
+	}
+}
+
+// TestScanAnonymousRowsWithRegistry
+// sql rows: [1, 0, 'mysql']
+// expect result: [[true,false,"mysql"]]
+func TestScanAnonymousRowsWithRegistry(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "enabled", DatabaseType: "TINYINT"},
+			{Name: "deleted", DatabaseType: "TINYINT"},
+			{Name: "name", DatabaseType: "VARCHAR"},
+		},
+		Rows: [][]any{{"1", "0", "mysql"}},
+	})
+	registry := NewDefaultRegistry()
+	if err := registry.Override(tinyIntBoolConverter); err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	anonymousRows, err := ScanAnonymousRowsWithRegistry(rows, registry)
+	if err != nil {
+		t.Fatalf("ScanAnonymousRowsWithRegistry() failed: %v", err)
+	}
+	bytes, err := json.Marshal(anonymousRows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const rawJson = "[[true,false,\"mysql\"]]"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+}
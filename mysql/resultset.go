@@ -0,0 +1,172 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Column metadata of a result set column from sql.ColumnType
+// Nullable, Length, Precision and Scale are nil when driver doesn't report them
+type Column struct {
+	Name         string `json:"name"`
+	DatabaseType string `json:"databaseType"`
+	Nullable     *bool  `json:"nullable,omitempty"`
+	Length       *int64 `json:"length,omitempty"`
+	Precision    *int64 `json:"precision,omitempty"`
+	Scale        *int64 `json:"scale,omitempty"`
+}
+
+// newColumn collect metadata of column type
+func newColumn(colType *sql.ColumnType) Column {
+	column := Column{
+		Name:         colType.Name(),
+		DatabaseType: colType.DatabaseTypeName(),
+	}
+	if nullable, ok := colType.Nullable(); ok {
+		column.Nullable = &nullable
+	}
+	if length, ok := colType.Length(); ok {
+		column.Length = &length
+	}
+	if precision, scale, ok := colType.DecimalSize(); ok {
+		column.Precision = &precision
+		column.Scale = &scale
+	}
+	return column
+}
+
+// ResultSet converted rows of one result set along with its columns
+// marshaled as {"columns": [...], "rows": [[...]...]}, columns first
+type ResultSet struct {
+	Columns []Column `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// MappedResultSet converted rows keyed by column name of one result set along with its columns
+type MappedResultSet struct {
+	Columns []Column         `json:"columns"`
+	Rows    []map[string]any `json:"rows"`
+}
+
+// ScanResultSet scan rows like Scan, along with metadata of columns in select order
+// rows scanned are returned along with *TruncatedError when stopped by WithMaxRows or WithMaxBytes
+func ScanResultSet(rows *sql.Rows, opts ...Option) (*ResultSet, error) {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return nil, err
+	}
+	resultSet := &ResultSet{Columns: scanner.columns()}
+	for scanner.Next() {
+		resultSet.Rows = append(resultSet.Rows, scanner.Row())
+	}
+	if err = scanner.Err(); err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return resultSet, err
+}
+
+// ScanMappedResultSet scan rows like ScanMapped, along with metadata of columns in select order
+// the order of map keys is lost, use Columns to restore it
+func ScanMappedResultSet(rows *sql.Rows, opts ...Option) (*MappedResultSet, error) {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err = scanner.mappedErr(); err != nil {
+		return nil, err
+	}
+	resultSet := &MappedResultSet{Columns: scanner.columns()}
+	for scanner.Next() {
+		mappedRow, err := scanner.MappedRow()
+		if err != nil {
+			return nil, err
+		}
+		resultSet.Rows = append(resultSet.Rows, mappedRow)
+	}
+	if err = scanner.Err(); err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return resultSet, err
+}
+
+// ScanResultSets scan every result set of rows, like results of stored procedure or multi statements
+// each result set is scanned like Scan, WithMaxRows and WithMaxBytes limit each result set
+// result sets scanned are returned along with *TruncatedError when any result set stopped by limits
+func ScanResultSets(rows *sql.Rows, opts ...Option) ([]ResultSet, error) {
+	var resultSets []ResultSet
+	err := walkResultSets(rows, opts, func(scanner *Scanner) error {
+		resultSet := ResultSet{Columns: scanner.columns()}
+		for scanner.Next() {
+			resultSet.Rows = append(resultSet.Rows, scanner.Row())
+		}
+		resultSets = append(resultSets, resultSet)
+		return scanner.Err()
+	})
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return resultSets, err
+}
+
+// ScanMappedResultSets same as ScanResultSets, but each row is a map keyed by column name
+func ScanMappedResultSets(rows *sql.Rows, opts ...Option) ([]MappedResultSet, error) {
+	var resultSets []MappedResultSet
+	err := walkResultSets(rows, opts, func(scanner *Scanner) error {
+		if err := scanner.mappedErr(); err != nil {
+			return err
+		}
+		resultSet := MappedResultSet{Columns: scanner.columns()}
+		for scanner.Next() {
+			mappedRow, err := scanner.MappedRow()
+			if err != nil {
+				return err
+			}
+			resultSet.Rows = append(resultSet.Rows, mappedRow)
+		}
+		resultSets = append(resultSets, resultSet)
+		return scanner.Err()
+	})
+	if err != nil && !isPartial(err) {
+		return nil, err
+	}
+	return resultSets, err
+}
+
+// walkResultSets call scan with scanner of each result set, stop at the first error except *TruncatedError
+// result sets after truncated one are still scanned, the first *TruncatedError is returned at the end
+func walkResultSets(rows *sql.Rows, opts []Option, scan func(*Scanner) error) error {
+	var truncatedErr error
+	for index := 0; ; index++ {
+		scanner, err := NewScanner(rows, opts...)
+		if err != nil {
+			return fmt.Errorf("scan result set %d failed, %w", index, err)
+		}
+		if err = scan(scanner); err != nil {
+			if !isTruncated(err) {
+				return fmt.Errorf("scan result set %d failed, %w", index, err)
+			}
+			if truncatedErr == nil {
+				truncatedErr = fmt.Errorf("scan result set %d failed, %w", index, err)
+			}
+		}
+		if !rows.NextResultSet() {
+			if err = rows.Err(); err != nil {
+				return fmt.Errorf("next result set failed, %w", err)
+			}
+			return truncatedErr
+		}
+	}
+}
+
+// columns metadata of columns in select order
+func (s *Scanner) columns() []Column {
+	columns := make([]Column, len(s.colTypes))
+	for i, colType := range s.colTypes {
+		columns[i] = newColumn(colType)
+	}
+	return columns
+}
@@ -0,0 +1,126 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// callProcedureResultSets result sets like CALL p() returning users then order count
+var callProcedureResultSets = []mysqltest.ResultSet{
+	{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT", Nullability: mysqltest.NotNull}, {Name: "name", DatabaseType: "VARCHAR"}},
+		Rows:    [][]any{{"1", "mysql"}, {"2", nil}},
+	},
+	{
+		Columns: []mysqltest.Column{{Name: "orders", DatabaseType: "BIGINT", Nullability: mysqltest.NotNull}},
+		Rows:    [][]any{{"42"}},
+	},
+}
+
+// TestScanResultSets
+// result sets: [[1, 'mysql'], [2, NULL]], [[42]]
+// expect result: each result set with its columns and rows
+func TestScanResultSets(t *testing.T) {
+	rows := queryFake(t, callProcedureResultSets...)
+	resultSets, err := ScanResultSets(rows)
+	if err != nil {
+		t.Fatalf("ScanResultSets() failed: %v", err)
+	}
+	bytes, err := json.Marshal(resultSets)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const rawJson = "[{\"columns\":[{\"name\":\"id\",\"databaseType\":\"BIGINT\",\"nullable\":false},{\"name\":\"name\",\"databaseType\":\"VARCHAR\",\"nullable\":true}],\"rows\":[[1,\"mysql\"],[2,null]]}," +
+		"{\"columns\":[{\"name\":\"orders\",\"databaseType\":\"BIGINT\",\"nullable\":false}],\"rows\":[[42]]}]"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+}
+
+// TestScanMappedResultSets
+// result sets: [[1, 'mysql'], [2, NULL]], [[42]]
+// expect result: rows of each result set keyed by its own columns
+func TestScanMappedResultSets(t *testing.T) {
+	rows := queryFake(t, callProcedureResultSets...)
+	resultSets, err := ScanMappedResultSets(rows)
+	if err != nil {
+		t.Fatalf("ScanMappedResultSets() failed: %v", err)
+	}
+	if len(resultSets) != 2 {
+		t.Fatalf("expect 2 result sets, got %d", len(resultSets))
+	}
+	bytes, err := json.Marshal(resultSets[1].Rows)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const mappedJson = "[{\"orders\":42}]"
+	if string(bytes) != mappedJson {
+		t.Errorf("expect %s, got %s", mappedJson, bytes)
+	}
+}
+
+// TestScanResultSet
+// sql rows: [12.50, 'mysql'] of DECIMAL(10,2) and VARCHAR(18)
+// expect result: columns with length, precision and scale first, then rows
+func TestScanResultSet(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "price", DatabaseType: "DECIMAL", Nullability: mysqltest.NotNull, Precision: 10, Scale: 2},
+			{Name: "name", DatabaseType: "VARCHAR", Length: 72},
+		},
+		Rows: [][]any{{"12.50", "mysql"}},
+	})
+	resultSet, err := ScanResultSet(rows)
+	if err != nil {
+		t.Fatalf("ScanResultSet() failed: %v", err)
+	}
+	bytes, err := json.Marshal(resultSet)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const rawJson = "{\"columns\":[{\"name\":\"price\",\"databaseType\":\"DECIMAL\",\"nullable\":false,\"precision\":10,\"scale\":2}," +
+		"{\"name\":\"name\",\"databaseType\":\"VARCHAR\",\"nullable\":true,\"length\":72}],\"rows\":[[12.50,\"mysql\"]]}"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+}
+
+// TestScanResultSetsTruncated
+// result sets: [[1], [2], [3]], [[4]], [[5], [6], [7]]
+// expect result: WithMaxRows(2) limits each result set, [[1], [2]], [[4]], [[5], [6]] along with *TruncatedError of result set 0
+func TestScanResultSetsTruncated(t *testing.T) {
+	column := []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}}
+	procedureResultSets := []mysqltest.ResultSet{
+		{Columns: column, Rows: [][]any{{"1"}, {"2"}, {"3"}}},
+		{Columns: column, Rows: [][]any{{"4"}}},
+		{Columns: column, Rows: [][]any{{"5"}, {"6"}, {"7"}}},
+	}
+	resultSets, err := ScanResultSets(queryFake(t, procedureResultSets...), WithMaxRows(2))
+	var truncated *TruncatedError
+	if !errors.As(err, &truncated) || !strings.Contains(err.Error(), "result set 0") {
+		t.Errorf("expect *TruncatedError of result set 0, got %v", err)
+	}
+	bytes, err := json.Marshal(resultSets)
+	if err != nil {
+		t.Errorf("json.Marshal() failed: %v", err)
+	}
+	const rawJson = "[{\"columns\":[{\"name\":\"id\",\"databaseType\":\"BIGINT\",\"nullable\":true}],\"rows\":[[1],[2]]}," +
+		"{\"columns\":[{\"name\":\"id\",\"databaseType\":\"BIGINT\",\"nullable\":true}],\"rows\":[[4]]}," +
+		"{\"columns\":[{\"name\":\"id\",\"databaseType\":\"BIGINT\",\"nullable\":true}],\"rows\":[[5],[6]]}]"
+	if string(bytes) != rawJson {
+		t.Errorf("expect %s, got %s", rawJson, bytes)
+	}
+
+	mappedResultSets, err := ScanMappedResultSets(queryFake(t, procedureResultSets...), WithMaxRows(2))
+	if !errors.As(err, &truncated) || len(mappedResultSets) != 3 || len(mappedResultSets[2].Rows) != 2 {
+		t.Errorf("expect 3 mapped result sets along with *TruncatedError, got %d %v", len(mappedResultSets), err)
+	}
+}
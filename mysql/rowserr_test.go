@@ -0,0 +1,143 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+var errConnectionDropped = errors.New("connection dropped")
+
+// brokenResultSet 2 rows then connection dropped
+var brokenResultSet = mysqltest.ResultSet{
+	Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "name", DatabaseType: "VARCHAR"}},
+	Rows:    [][]any{{"1", "a"}, {"2", "b"}},
+	Err:     errConnectionDropped,
+}
+
+// unscannableResultSet value of row 2 can't be scanned into string by database/sql
+var unscannableResultSet = mysqltest.ResultSet{
+	Columns: []mysqltest.Column{{Name: "id", DatabaseType: "BIGINT"}, {Name: "name", DatabaseType: "VARCHAR"}},
+	Rows:    [][]any{{"1", "a"}, {"2", struct{}{}}},
+}
+
+// scanFuncs every scan function, returning count of rows got along with error
+var scanFuncs = map[string]func(rows *sql.Rows) (int, error){
+	"ScanAnonymousRows": func(rows *sql.Rows) (int, error) {
+		allRows, err := ScanAnonymousRows(rows)
+		return len(allRows), err
+	},
+	"ScanAnonymousMappedRows": func(rows *sql.Rows) (int, error) {
+		allRows, err := ScanAnonymousMappedRows(rows)
+		return len(allRows), err
+	},
+	"DeprecatedScanAnonymousRows": func(rows *sql.Rows) (int, error) {
+		allRows, err := DeprecatedScanAnonymousRows(rows)
+		return len(allRows), err
+	},
+	"DeprecatedScanAnonymousMappedRows": func(rows *sql.Rows) (int, error) {
+		allRows, err := DeprecatedScanAnonymousMappedRows(rows)
+		return len(allRows), err
+	},
+	"ScanOrderedRows": func(rows *sql.Rows) (int, error) {
+		allRows, err := ScanOrderedRows(rows)
+		return len(allRows), err
+	},
+	"ScanStructs": func(rows *sql.Rows) (int, error) {
+		allRows, err := ScanStructs(rows)
+		if allRows == nil {
+			return 0, err
+		}
+		return reflect.ValueOf(allRows).Len(), err
+	},
+	"ScanInto": func(rows *sql.Rows) (int, error) {
+		var allRows []struct{ ID int64 }
+		err := ScanInto(rows, &allRows)
+		return len(allRows), err
+	},
+	"GroupMapped": func(rows *sql.Rows) (int, error) {
+		groups, err := GroupMapped(rows, []string{"id"})
+		return len(groups), err
+	},
+	"ScanMappedResultSets": func(rows *sql.Rows) (int, error) {
+		resultSets, err := ScanMappedResultSets(rows)
+		return len(resultSets), err
+	},
+	"ScanContext": func(rows *sql.Rows) (int, error) {
+		allRows, err := ScanContext(context.Background(), rows)
+		return len(allRows), err
+	},
+}
+
+// TestScanIterationError
+// sql rows: [1, 'a'], [2, 'b'], then connection dropped
+// expect result: every scan function returns error wrapping the driver error and no rows, not a short result
+func TestScanIterationError(t *testing.T) {
+	for name, scan := range scanFuncs {
+		t.Run(name, func(t *testing.T) {
+			count, err := scan(queryFake(t, brokenResultSet))
+			if !errors.Is(err, errConnectionDropped) {
+				t.Fatalf("expect connection dropped error, got %v", err)
+			}
+			if !strings.Contains(err.Error(), "iterate rows failed") {
+				t.Errorf("expect wrapped error, got %v", err)
+			}
+			if count != 0 {
+				t.Errorf("expect no rows, got %d", count)
+			}
+		})
+	}
+}
+
+// TestScanRowError
+// sql rows: [1, 'a'], [2, struct{}{}]
+// expect result: every scan function returns wrapped scan error without panic
+func TestScanRowError(t *testing.T) {
+	for name, scan := range scanFuncs {
+		t.Run(name, func(t *testing.T) {
+			count, err := scan(queryFake(t, unscannableResultSet))
+			if err == nil || !strings.Contains(err.Error(), "scan row failed") {
+				t.Fatalf("expect scan row error, got %v", err)
+			}
+			if count != 0 {
+				t.Errorf("expect no rows, got %d", count)
+			}
+		})
+	}
+}
+
+// TestQueryIterationError
+// sql rows: same as TestScanIterationError
+// expect result: QueryMapped returns the error after closing rows
+func TestQueryIterationError(t *testing.T) {
+	db := newFakeDB(t, brokenResultSet)
+	if _, err := QueryMapped(context.Background(), db, "SELECT ..."); !errors.Is(err, errConnectionDropped) {
+		t.Errorf("expect connection dropped error, got %v", err)
+	}
+}
+
+// TestScannerIterationError
+// sql rows: same as TestScanIterationError
+// expect result: Scanner yields 2 rows, then Err reports the driver error
+func TestScannerIterationError(t *testing.T) {
+	scanner, err := NewScanner(queryFake(t, brokenResultSet))
+	if err != nil {
+		t.Fatalf("NewScanner() failed: %v", err)
+	}
+	count := 0
+	for scanner.Next() {
+		count++
+	}
+	if count != 2 || !errors.Is(scanner.Err(), errConnectionDropped) {
+		t.Errorf("expect 2 rows then connection dropped, got %d rows and %v", count, scanner.Err())
+	}
+}
@@ -6,213 +6,112 @@ package mysql
 
 import (
 	"database/sql"
-	"fmt"
-	"reflect"
-	"slices"
 )
 
-// DeprecatedScanAnonymousRows scan anonymous rows without predefined struct, using simply converter match with sql types
-// cols type related with time, datetime, date, timestamp will be converted to utc time, timestamp will be datetime, json will be string
+// Scan scan anonymous rows without predefined struct, converting values by mysql types under given options
+// without options, cols type related with time(datetime,date) will be converted to local time, timestamp will be number, json will be json
+// rows scanned are returned along with *TruncatedError when stopped by WithMaxRows or WithMaxBytes
 // return format likes: [[number, 'string', '0000-00-00T00:00:00Z',...]...]
-func DeprecatedScanAnonymousRows(rows *sql.Rows) ([][]any, error) {
-	// get col types for type convert
-	colTypes, err := rows.ColumnTypes()
+func Scan(rows *sql.Rows, opts ...Option) ([][]any, error) {
+	scanner, err := NewScanner(rows, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("get colTypes failed, %w", err)
-	}
-	var colScanTypes []reflect.Type
-	for _, colType := range colTypes {
-		colScanTypes = append(colScanTypes, colType.ScanType())
-	}
-	// prepare for scan
-	scanArgs := make([]interface{}, len(colTypes))
-	values := make([]*string, len(colTypes))
-	for i := range values {
-		scanArgs[i] = &values[i]
+		return nil, err
 	}
 	var allValues [][]any
-
-	// native scan rows
-	for rows.Next() {
-		err = rows.Scan(scanArgs...)
-		if err != nil {
-			return nil, fmt.Errorf("scan row failed, %w", err)
-		}
-		typedValues := make([]interface{}, len(colTypes))
-	ValueLoop:
-		for i, stringV := range values {
-			for _, converter := range SimplySQLTypeConverters {
-				if colScanTypes[i] == converter.InputType {
-					convertedValue, err := converter.ReplaceFunc(stringV)
-					if err != nil {
-						return nil, fmt.Errorf("convert value failed, %w", err)
-					}
-					typedValues[i] = convertedValue
-					continue ValueLoop
-				}
-			}
-			typedValues[i] = stringV
+	for scanner.Next() {
+		allValues = append(allValues, scanner.Row())
+	}
+	if err = scanner.Err(); err != nil {
+		if isPartial(err) {
+			return allValues, err
 		}
-		allValues = append(allValues, typedValues)
+		return nil, err
 	}
 	return allValues, nil
 }
 
-// DeprecatedScanAnonymousMappedRows scan anonymous rows without predefined struct, using simply converter match with sql types
-// cols type related with time, datetime, date, timestamp will be converted to utc time, timestamp will be datetime, json will be string
-// return format likes: [{'col1': number, 'col2': 'string', 'col3': '0000-00-00T00:00:00Z',...}...]
-func DeprecatedScanAnonymousMappedRows(rows *sql.Rows) ([]map[string]any, error) {
-	colTypes, err := rows.ColumnTypes()
+// ScanMapped same as Scan, but each row is a map keyed by column name
+// return format likes: [{'col1': number, 'col2': 'string', 'col3': '0000-00-00T00:00:00±0:00',...}...]
+func ScanMapped(rows *sql.Rows, opts ...Option) ([]map[string]any, error) {
+	scanner, err := NewScanner(rows, opts...)
 	if err != nil {
-		return nil, fmt.Errorf("get colTypes failed, %w", err)
-	}
-	// get col names
-	var colNames []string
-	for _, colType := range colTypes {
-		if slices.Contains(colNames, colType.Name()) {
-			return nil, fmt.Errorf("duplicate column name %s", colType.Name())
-		}
-		colNames = append(colNames, colType.Name())
-	}
-	// get col types
-	var colScanTypes []reflect.Type
-	for _, colType := range colTypes {
-		colScanTypes = append(colScanTypes, colType.ScanType())
+		return nil, err
 	}
-	// prepare for scan
-	scanArgs := make([]interface{}, len(colTypes))
-	values := make([]*string, len(colTypes))
-	for i := range values {
-		scanArgs[i] = &values[i]
+	if err = scanner.mappedErr(); err != nil {
+		return nil, err
 	}
-
 	var allRows []map[string]any
-
-	// native scan rows
-	for rows.Next() {
-		err = rows.Scan(scanArgs...)
+	for scanner.Next() {
+		mappedRow, err := scanner.MappedRow()
 		if err != nil {
-			panic(err)
-		}
-		var mappedRow = map[string]any{}
-	ValueLoop:
-		for i, stringV := range values {
-			for _, converter := range SimplySQLTypeConverters {
-				if colScanTypes[i] == converter.InputType {
-					convertedValue, err := converter.ReplaceFunc(stringV)
-					if err != nil {
-						return nil, fmt.Errorf("convert value failed, %w", err)
-					}
-					mappedRow[colNames[i]] = convertedValue
-					continue ValueLoop
-				}
-			}
-			mappedRow[colNames[i]] = stringV
+			return nil, err
 		}
 		allRows = append(allRows, mappedRow)
 	}
+	if err = scanner.Err(); err != nil {
+		if isPartial(err) {
+			return allRows, err
+		}
+		return nil, err
+	}
 	return allRows, nil
 }
 
+// DeprecatedScanAnonymousRows scan anonymous rows without predefined struct
+// cols type related with time, datetime, date, timestamp will be converted to utc time, timestamp will be datetime, json will be string
+// values are plain like ValueAsPlain: int64, float64, string, time.Time, untyped nil for NULL
+// see DeprecatedScanAnonymousRowsWith for values by scan type of driver as before
+// return format likes: [[number, 'string', '0000-00-00T00:00:00Z',...]...]
+func DeprecatedScanAnonymousRows(rows *sql.Rows) ([][]any, error) {
+	return Scan(rows, deprecatedOptions...)
+}
+
+// DeprecatedScanAnonymousRowsWith same as DeprecatedScanAnonymousRows, with given options applied after its own
+// with WithValueMode(ValueAsPointer), values are *int64, *float64, **string, *time.Time and *string by scan type of driver as before
+// converted by scan type then, options of conversion like WithRegistry, WithLocation, WithTimestampAs, WithJSONMode,
+// WithZeroDate and WithBinaryEncoding can't apply and fail the scan, rows limits, keys of mapped rows and WithLenient still apply
+func DeprecatedScanAnonymousRowsWith(rows *sql.Rows, opts ...Option) ([][]any, error) {
+	return Scan(rows, append(deprecatedOptions[:len(deprecatedOptions):len(deprecatedOptions)], opts...)...)
+}
+
+// DeprecatedScanAnonymousMappedRows scan anonymous rows without predefined struct
+// cols type related with time, datetime, date, timestamp will be converted to utc time, timestamp will be datetime, json will be string
+// values are plain like DeprecatedScanAnonymousRows, see DeprecatedScanAnonymousMappedRowsWith for values by scan type as before
+// return format likes: [{'col1': number, 'col2': 'string', 'col3': '0000-00-00T00:00:00Z',...}...]
+func DeprecatedScanAnonymousMappedRows(rows *sql.Rows) ([]map[string]any, error) {
+	return ScanMapped(rows, deprecatedOptions...)
+}
+
+// DeprecatedScanAnonymousMappedRowsWith same as DeprecatedScanAnonymousMappedRows, with given options applied after its own
+// with WithValueMode(ValueAsPointer), values are by scan type of driver and options are limited like DeprecatedScanAnonymousRowsWith
+func DeprecatedScanAnonymousMappedRowsWith(rows *sql.Rows, opts ...Option) ([]map[string]any, error) {
+	return ScanMapped(rows, append(deprecatedOptions[:len(deprecatedOptions):len(deprecatedOptions)], opts...)...)
+}
+
 // ScanAnonymousRows scan anonymous rows without predefined struct, using simply converter match with sql types
 // cols type related with time(datetime,date,timestamp) will be converted to local time, timestamp will be datetime, json will be json
+// same as Scan without options, Scan(rows, WithValueMode(ValueAsPointer)) returns pointer values as before
 // return format likes: [[number, 'string', '0000-00-00T00:00:00Z',...]...]
 func ScanAnonymousRows(rows *sql.Rows) ([][]any, error) {
-	// get col types for type convert
-	colTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("get colTypes failed, %w", err)
-	}
-	var colMySQLTypes []string
-	for _, colType := range colTypes {
-		colMySQLTypes = append(colMySQLTypes, colType.DatabaseTypeName())
-	}
-	// prepare for scan
-	scanArgs := make([]interface{}, len(colTypes))
-	values := make([]*string, len(colTypes))
-	for i := range values {
-		scanArgs[i] = &values[i]
-	}
-	var allValues [][]any
+	return Scan(rows)
+}
 
-	// native scan rows
-	for rows.Next() {
-		err = rows.Scan(scanArgs...)
-		if err != nil {
-			return nil, fmt.Errorf("scan row failed, %w", err)
-		}
-		typedValues := make([]interface{}, len(colTypes))
-	ValueLoop:
-		for i, stringV := range values {
-			for _, converter := range mysqlTypeConverters {
-				if colMySQLTypes[i] == converter.MySQLType {
-					convertedValue, err := converter.ReplaceFunc(stringV)
-					if err != nil {
-						return nil, fmt.Errorf("convert value failed, %w", err)
-					}
-					typedValues[i] = convertedValue
-					continue ValueLoop
-				}
-			}
-			typedValues[i] = stringV
-		}
-		allValues = append(allValues, typedValues)
-	}
-	return allValues, nil
+// ScanAnonymousRowsWithRegistry same as ScanAnonymousRows, but convert values with converters of given registry
+// column whose database type has no converter in registry keeps raw value
+func ScanAnonymousRowsWithRegistry(rows *sql.Rows, registry *Registry) ([][]any, error) {
+	return Scan(rows, WithRegistry(registry))
 }
 
 // ScanAnonymousMappedRows scan anonymous rows without predefined struct, using grafana converter match with mysql types
 // cols type related with time, datetime, date, timestamp will be converted to local time, timestamp will be number
+// same as ScanMapped without options, ScanMapped(rows, WithValueMode(ValueAsPointer)) returns pointer values as before
 // return format likes: [{number, 'string', '0000-00-00T00:00:00±0:00',...}...]
 func ScanAnonymousMappedRows(rows *sql.Rows) ([]map[string]any, error) {
-	colTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("get colTypes failed, %w", err)
-	}
-
-	var colNames []string
-	for _, colType := range colTypes {
-		if slices.Contains(colNames, colType.Name()) {
-			return nil, fmt.Errorf("duplicate column name %s", colType.Name())
-		}
-		colNames = append(colNames, colType.Name())
-	}
-
-	var colMySQLTypes []string
-	for _, colType := range colTypes {
-		// useful debug line:
-		// fmt.Println(fmt.Sprintf("type %s %s %s", colType.Name(), colType.DatabaseTypeName(), colType.ScanType()))
-		colMySQLTypes = append(colMySQLTypes, colType.DatabaseTypeName())
-	}
-
-	scanArgs := make([]interface{}, len(colTypes))
-	values := make([]*string, len(colTypes))
-	for i := range values {
-		scanArgs[i] = &values[i]
-	}
-	var allRows []map[string]any
+	return ScanMapped(rows)
+}
 
-	for rows.Next() {
-		err = rows.Scan(scanArgs...)
-		if err != nil {
-			return nil, fmt.Errorf("scan row failed, %w", err)
-		}
-		var mappedRow = map[string]any{}
-	ValueLoop:
-		for i, stringV := range values {
-			for _, converter := range mysqlTypeConverters {
-				if colMySQLTypes[i] == converter.MySQLType {
-					convertedValue, err := converter.ReplaceFunc(stringV)
-					if err != nil {
-						return nil, fmt.Errorf("convert value failed, %w", err)
-					}
-					mappedRow[colNames[i]] = convertedValue
-					continue ValueLoop
-				}
-			}
-			mappedRow[colNames[i]] = stringV
-		}
-		allRows = append(allRows, mappedRow)
-	}
-	return allRows, nil
+// ScanAnonymousMappedRowsWithRegistry same as ScanAnonymousMappedRows, but convert values with converters of given registry
+// column whose database type has no converter in registry keeps raw value
+func ScanAnonymousMappedRowsWithRegistry(rows *sql.Rows, registry *Registry) ([]map[string]any, error) {
+	return ScanMapped(rows, WithRegistry(registry))
 }
@@ -0,0 +1,246 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Scanner stream anonymous rows one by one without loading whole result set into memory
+// values are converted with the same converters and options as Scan
+//
+//	scanner, err := NewScanner(rows)
+//	for scanner.Next() {
+//		row := scanner.Row()
+//	}
+//	err = scanner.Err()
+type Scanner struct {
+	cfg          *config
+	rows         *sql.Rows
+	colNames     []string
+	keys         *columnKeys
+	keyIndex     map[string]int
+	colTypes     []*sql.ColumnType
+	replaceFuncs []func(*string) (any, error)
+	scanArgs     []any
+	values       []*string
+	row          []any
+	// failed cells of current row failed to convert under WithLenient
+	failed       []bool
+	rowCount     int
+	byteCount    int64
+	nestedPaths  [][]string
+	duplicateErr error
+	nestedErr    error
+	err          error
+}
+
+// NewScanner create scanner of rows converting values under given options
+func NewScanner(rows *sql.Rows, opts ...Option) (*Scanner, error) {
+	cfg := newConfig(opts...)
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("get colTypes failed, %w", err)
+	}
+	var colNames []string
+	var colMySQLTypes []string
+	for _, colType := range colTypes {
+		colNames = append(colNames, colType.Name())
+		colMySQLTypes = append(colMySQLTypes, colType.DatabaseTypeName())
+	}
+	// keys of mapped rows, Row works regardless of duplicate error
+	keys, duplicateErr := resolveColumnKeys(colNames, cfg.duplicates)
+	var nestedPaths [][]string
+	var nestedErr error
+	if keys != nil && cfg.nestSeparator != "" {
+		nestedPaths, nestedErr = resolveNestedPaths(keys.names, cfg.nestSeparator)
+	}
+	// prepare for scan, buffers are reused between rows
+	scanArgs := make([]any, len(colTypes))
+	values := make([]*string, len(colTypes))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	replaceFuncs := cfg.registryOrDefault().replaceFuncs(cfg, colMySQLTypes)
+	if cfg.scanTypeConverters && cfg.valueMode == ValueAsPointer {
+		if err = cfg.scanTypeErr(); err != nil {
+			return nil, err
+		}
+		replaceFuncs = scanTypeReplaceFuncs(colTypes)
+	}
+	return &Scanner{
+		cfg:          cfg,
+		rows:         rows,
+		colNames:     colNames,
+		keys:         keys,
+		colTypes:     colTypes,
+		replaceFuncs: replaceFuncs,
+		scanArgs:     scanArgs,
+		values:       values,
+		nestedPaths:  nestedPaths,
+		duplicateErr: duplicateErr,
+		nestedErr:    nestedErr,
+	}, nil
+}
+
+// NewScannerWithRegistry create scanner of rows converting values with given registry
+func NewScannerWithRegistry(rows *sql.Rows, registry *Registry) (*Scanner, error) {
+	return NewScanner(rows, WithRegistry(registry))
+}
+
+// Columns names of columns in select order
+func (s *Scanner) Columns() []string {
+	return s.colNames
+}
+
+// ColumnTypes column types reported by driver
+func (s *Scanner) ColumnTypes() []*sql.ColumnType {
+	return s.colTypes
+}
+
+// Next scan and convert next row, return false when rows exhausted or failed, check Err after that
+func (s *Scanner) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	if err := s.cfg.ctx.Err(); err != nil {
+		s.err = fmt.Errorf("scan rows canceled, %w", err)
+		return false
+	}
+	if !s.rows.Next() {
+		if err := s.rows.Err(); err != nil {
+			s.err = fmt.Errorf("iterate rows failed, %w", err)
+		}
+		return false
+	}
+	if s.cfg.maxRows > 0 && s.rowCount >= s.cfg.maxRows {
+		s.err = &TruncatedError{MaxRows: s.cfg.maxRows, Rows: s.rowCount, Bytes: s.byteCount}
+		return false
+	}
+	if err := s.rows.Scan(s.scanArgs...); err != nil {
+		s.err = fmt.Errorf("scan row failed, %w", err)
+		return false
+	}
+	if s.cfg.maxBytes > 0 {
+		var rowBytes int64
+		for _, stringV := range s.values {
+			if stringV != nil {
+				rowBytes += int64(len(*stringV))
+			}
+		}
+		if s.byteCount+rowBytes > s.cfg.maxBytes {
+			s.err = &TruncatedError{MaxBytes: s.cfg.maxBytes, Rows: s.rowCount, Bytes: s.byteCount}
+			return false
+		}
+		s.byteCount += rowBytes
+	}
+	typedValues := make([]any, len(s.values))
+	s.failed = nil
+	for i, stringV := range s.values {
+		if s.replaceFuncs[i] == nil {
+			typedValues[i] = s.rawValue(stringV)
+			continue
+		}
+		convertedValue, err := s.replaceFuncs[i](stringV)
+		if err != nil {
+			convErr := s.conversionError(s.rowCount, i, err)
+			if !s.cfg.lenient {
+				s.err = convErr
+				return false
+			}
+			if s.cfg.report != nil {
+				s.cfg.report.add(convErr)
+			}
+			if s.failed == nil {
+				s.failed = make([]bool, len(s.values))
+			}
+			s.failed[i] = true
+			if s.cfg.fallback == FallbackAsRaw {
+				typedValues[i] = s.rawValue(stringV)
+			}
+			continue
+		}
+		typedValues[i] = convertedValue
+	}
+	s.row = typedValues
+	s.rowCount++
+	return true
+}
+
+// conversionError failure of column i in row with raw value of current row
+func (s *Scanner) conversionError(row, i int, err error) *ConversionError {
+	var raw string
+	if s.values[i] != nil {
+		raw = *s.values[i]
+	}
+	return &ConversionError{
+		Row:          row,
+		Column:       s.colNames[i],
+		DatabaseType: s.colTypes[i].DatabaseTypeName(),
+		Raw:          raw,
+		Err:          err,
+	}
+}
+
+// reportFailure report failure of column i in the row returned by last Next under WithLenient
+// cell failed to convert is reported once by Next
+func (s *Scanner) reportFailure(i int, err error) {
+	if s.failed != nil && s.failed[i] {
+		return
+	}
+	if s.cfg.report != nil {
+		s.cfg.report.add(s.conversionError(s.rowCount-1, i, err))
+	}
+}
+
+// rawValue raw value of column without converter, or fallback of WithLenient
+func (s *Scanner) rawValue(stringV *string) any {
+	if s.cfg.valueMode == ValueAsPointer {
+		return stringV
+	}
+	if stringV == nil {
+		return nil
+	}
+	return *stringV
+}
+
+// Row converted values of current row, format likes: [number, 'string', '0000-00-00T00:00:00Z',...]
+// the returned slice is not reused by later Next calls
+func (s *Scanner) Row() []any {
+	return s.row
+}
+
+// MappedRow converted values of current row keyed by column name, format likes: {'col1': number, 'col2': 'string',...}
+// duplicate column names are keyed following WithDuplicateColumns, error by default
+// nested with WithNestedKeys
+func (s *Scanner) MappedRow() (map[string]any, error) {
+	if err := s.mappedErr(); err != nil {
+		return nil, err
+	}
+	values := s.keys.keyedValues(s.row)
+	if s.nestedPaths != nil {
+		return nestValues(s.nestedPaths, values), nil
+	}
+	mappedRow := make(map[string]any, len(values))
+	for i, key := range s.keys.names {
+		mappedRow[key] = values[i]
+	}
+	return mappedRow, nil
+}
+
+// mappedErr error resolving keys of mapped rows from column names
+func (s *Scanner) mappedErr() error {
+	if s.duplicateErr != nil {
+		return s.duplicateErr
+	}
+	return s.nestedErr
+}
+
+// Err first error met by Next
+// *TruncatedError when stopped by WithMaxRows or WithMaxBytes, rows before are valid
+func (s *Scanner) Err() error {
+	return s.err
+}
@@ -0,0 +1,93 @@
+//go:build go1.23
+
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"iter"
+)
+
+// All iterate converted rows of scanner, iteration stops after yielding the first error
+//
+//	for row, err := range scanner.All() {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (s *Scanner) All() iter.Seq2[[]any, error] {
+	return func(yield func([]any, error) bool) {
+		for s.Next() {
+			if !yield(s.Row(), nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// AllMapped iterate converted rows of scanner keyed by column name, iteration stops after yielding the first error
+func (s *Scanner) AllMapped() iter.Seq2[map[string]any, error] {
+	return func(yield func(map[string]any, error) bool) {
+		if err := s.mappedErr(); err != nil {
+			yield(nil, err)
+			return
+		}
+		for s.Next() {
+			mappedRow, _ := s.MappedRow()
+			if !yield(mappedRow, nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// AllOrdered iterate converted rows of scanner keeping select order of columns, iteration stops after yielding the first error
+// keys are not nested like OrderedRow
+func (s *Scanner) AllOrdered() iter.Seq2[*OrderedRow, error] {
+	return func(yield func(*OrderedRow, error) bool) {
+		if err := s.flatErr(); err != nil {
+			yield(nil, err)
+			return
+		}
+		for s.Next() {
+			orderedRow, _ := s.OrderedRow()
+			if !yield(orderedRow, nil) {
+				return
+			}
+		}
+		if err := s.Err(); err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// IterAnonymousRows streaming version of Scan
+func IterAnonymousRows(rows *sql.Rows, opts ...Option) iter.Seq2[[]any, error] {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return func(yield func([]any, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return scanner.All()
+}
+
+// IterAnonymousMappedRows streaming version of ScanMapped
+func IterAnonymousMappedRows(rows *sql.Rows, opts ...Option) iter.Seq2[map[string]any, error] {
+	scanner, err := NewScanner(rows, opts...)
+	if err != nil {
+		return func(yield func(map[string]any, error) bool) {
+			yield(nil, err)
+		}
+	}
+	return scanner.AllMapped()
+}
@@ -0,0 +1,33 @@
+//go:build go1.23
+
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestIterAnonymousMappedRows
+// sql rows: [1, 'mysql'], [2, 'mariadb'], break after first row
+func TestIterAnonymousMappedRows(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: t1.Columns,
+		Rows:    [][]any{{int64(1), "mysql"}, {int64(2), "mariadb"}},
+	})
+	var names []string
+	for row, err := range IterAnonymousMappedRows(rows) {
+		if err != nil {
+			t.Fatalf("IterAnonymousMappedRows() failed: %v", err)
+		}
+		names = append(names, row["name"].(string))
+		break
+	}
+	if len(names) != 1 || names[0] != "mysql" {
+		t.Errorf("expect [mysql], got %v", names)
+	}
+}
@@ -0,0 +1,67 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+// TestScanner
+// sql rows: [1, 'mysql'], [2, NULL]
+// expect result: [[1,"mysql"],[2,null]] row by row
+func TestScanner(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: t1.Columns,
+		Rows:    [][]any{{int64(1), "mysql"}, {int64(2), nil}},
+	})
+	scanner, err := NewScanner(rows)
+	if err != nil {
+		t.Fatalf("NewScanner() failed: %v", err)
+	}
+	expects := []string{"[1,\"mysql\"]", "[2,null]"}
+	var count int
+	for scanner.Next() {
+		bytes, err := json.Marshal(scanner.Row())
+		if err != nil {
+			t.Errorf("json.Marshal() failed: %v", err)
+		}
+		if count >= len(expects) || string(bytes) != expects[count] {
+			t.Errorf("row %d got %s", count, bytes)
+		}
+		count++
+	}
+	if err = scanner.Err(); err != nil {
+		t.Errorf("scanner.Err() got %v", err)
+	}
+	if count != len(expects) {
+		t.Errorf("expect %d rows, got %d", len(expects), count)
+	}
+}
+
+// TestScannerDuplicateColumn
+// sql: SELECT id, id FROM t1
+// expect MappedRow failed with duplicate column name, Row works
+func TestScannerDuplicateColumn(t *testing.T) {
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{{Name: "id", DatabaseType: "INT"}, {Name: "id", DatabaseType: "INT"}},
+		Rows:    [][]any{{int64(1), int64(1)}},
+	})
+	scanner, err := NewScanner(rows)
+	if err != nil {
+		t.Fatalf("NewScanner() failed: %v", err)
+	}
+	if !scanner.Next() {
+		t.Fatalf("scanner.Next() failed: %v", scanner.Err())
+	}
+	if len(scanner.Row()) != 2 {
+		t.Errorf("expect 2 values, got %v", scanner.Row())
+	}
+	if _, err = scanner.MappedRow(); err == nil {
+		t.Errorf("MappedRow() expect duplicate column error")
+	}
+}
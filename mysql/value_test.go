@@ -0,0 +1,330 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/naughtyGitCat/anonymous-query-scan/mysql/mysqltest"
+)
+
+func scanValueModeRows(t *testing.T, opts ...Option) [][]any {
+	t.Helper()
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "score", DatabaseType: "DOUBLE"},
+			{Name: "count", DatabaseType: "UNSIGNED INT"},
+			{Name: "name", DatabaseType: "VARCHAR"},
+			{Name: "content", DatabaseType: "JSON"},
+			{Name: "created_at", DatabaseType: "DATETIME"},
+			{Name: "area", DatabaseType: "GEOMETRY"},
+			{Name: "price", DatabaseType: "DECIMAL"},
+		},
+		Rows: [][]any{
+			{"1", "9.5", "3", "mysql", "{\"a\": 1}", "2024-01-01 10:00:00", "raw", "9.50"},
+			{nil, nil, nil, nil, nil, nil, nil, nil},
+		},
+	})
+	allRows, err := Scan(rows, opts...)
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	return allRows
+}
+
+// TestValueAsPlain
+// sql rows: [1, 9.5, 3, 'mysql', '{"a": 1}', '2024-01-01 10:00:00', 'raw', 9.50], [NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL]
+// expect result: plain values, untyped nil for NULL
+func TestValueAsPlain(t *testing.T) {
+	allRows := scanValueModeRows(t, WithLocation(time.UTC))
+	expectTypes := []string{"int64", "float64", "uint64", "string", "map[string]interface {}", "time.Time", "string", "mysql.Decimal"}
+	for i, v := range allRows[0] {
+		if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+			t.Errorf("column %d expect %s, got %s", i, expectTypes[i], got)
+		}
+	}
+	if allRows[0][0] != int64(1) || allRows[0][3] != "mysql" || allRows[0][6] != "raw" {
+		t.Errorf("unexpected row %v", allRows[0])
+	}
+	for i, v := range allRows[1] {
+		if v != nil {
+			t.Errorf("column %d expect untyped nil, got %#v", i, v)
+		}
+	}
+}
+
+// TestValueAsPointer
+// sql rows: same as TestValueAsPlain
+// expect result: pointer values as before ValueMode, DECIMAL is *float64
+// UNSIGNED INT, VARCHAR and GEOMETRY had no converter, raw *string and nil *string for NULL
+func TestValueAsPointer(t *testing.T) {
+	allRows := scanValueModeRows(t, WithLocation(time.UTC), WithValueMode(ValueAsPointer))
+	expectTypes := []string{"*int64", "*float64", "*string", "*string", "*interface {}", "time.Time", "*string", "*float64"}
+	for i, v := range allRows[0] {
+		if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+			t.Errorf("column %d expect %s, got %s", i, expectTypes[i], got)
+		}
+	}
+	if *allRows[0][0].(*int64) != 1 || *allRows[0][3].(*string) != "mysql" || *allRows[0][6].(*string) != "raw" {
+		t.Errorf("unexpected row %v", allRows[0])
+	}
+	for _, i := range []int{0, 1, 4, 5} {
+		if allRows[1][i] != nil {
+			t.Errorf("column %d expect untyped nil, got %#v", i, allRows[1][i])
+		}
+	}
+	for _, i := range []int{2, 3, 6} {
+		if raw, ok := allRows[1][i].(*string); !ok || raw != nil {
+			t.Errorf("column %d expect nil *string of NULL, got %#v", i, allRows[1][i])
+		}
+	}
+	if price := allRows[0][7].(*float64); *price != 9.5 || allRows[1][7] != nil {
+		t.Errorf("expect *float64 9.5 and nil of DECIMAL, got %v %#v", *price, allRows[1][7])
+	}
+
+	allRows = scanValueModeRows(t, WithValueMode(ValueAsPointer), WithJSONMode(JSONAsString))
+	if content, ok := allRows[0][4].(*string); !ok || *content != "{\"a\": 1}" {
+		t.Errorf("expect *string of JSON text, got %#v", allRows[0][4])
+	}
+}
+
+// TestValueAsPointerOverridden
+// sql rows: [9.50]
+// expect result: DECIMAL converter looked up and overridden with own ReplaceFunc and nil Configure keeps its value under ValueAsPointer
+func TestValueAsPointerOverridden(t *testing.T) {
+	registry := NewDefaultRegistry()
+	converter, _ := registry.Lookup("DECIMAL")
+	converter.Configure = nil
+	converter.ReplaceFunc = func(in *string) (any, error) {
+		if in == nil {
+			return nil, nil
+		}
+		return "custom " + *in, nil
+	}
+	if err := registry.Override(converter); err != nil {
+		t.Fatalf("Override() failed: %v", err)
+	}
+	for _, mode := range []ValueMode{ValueAsPlain, ValueAsPointer} {
+		rows := queryFake(t, mysqltest.ResultSet{
+			Columns: []mysqltest.Column{{Name: "price", DatabaseType: "DECIMAL"}},
+			Rows:    [][]any{{"9.50"}},
+		})
+		allRows, err := Scan(rows, WithRegistry(registry), WithValueMode(mode))
+		if err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		if allRows[0][0] != "custom 9.50" {
+			t.Errorf("value mode %d expect custom value, got %#v", mode, allRows[0][0])
+		}
+	}
+}
+
+// TestValueAsPointerRawTypes
+// sql rows: ['12:00:00', 'a,b', x'01', x'ff', 'x', 1], [NULL, NULL, NULL, NULL, NULL, NULL]
+// expect result: types without converter before ValueMode are raw *string under ValueAsPointer, nil *string for NULL
+func TestValueAsPointerRawTypes(t *testing.T) {
+	columns := []mysqltest.Column{
+		{Name: "duration", DatabaseType: "TIME"},
+		{Name: "tags", DatabaseType: "SET"},
+		{Name: "flag", DatabaseType: "BIT"},
+		{Name: "content", DatabaseType: "BLOB"},
+		{Name: "kind", DatabaseType: "ENUM"},
+		{Name: "level", DatabaseType: "MEDIUMINT"},
+	}
+	rows := queryFake(t, mysqltest.ResultSet{
+		Columns: columns,
+		Rows: [][]any{
+			{"12:00:00", "a,b", "\x01", "\xff", "x", "1"},
+			{nil, nil, nil, nil, nil, nil},
+		},
+	})
+	allRows, err := Scan(rows, WithValueMode(ValueAsPointer))
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	for i, column := range columns {
+		if raw, ok := allRows[0][i].(*string); !ok || raw == nil {
+			t.Errorf("column %s expect *string, got %#v", column.Name, allRows[0][i])
+		}
+		if raw, ok := allRows[1][i].(*string); !ok || raw != nil {
+			t.Errorf("column %s expect nil *string of NULL, got %#v", column.Name, allRows[1][i])
+		}
+	}
+}
+
+// TestDeprecatedScanValueTypes
+// sql rows: same as TestValueAsPlain
+// expect result: deprecated functions return plain values, utc time.Time of DATETIME, string of JSON, untyped nil for NULL
+func TestDeprecatedScanValueTypes(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "score", DatabaseType: "DOUBLE"},
+			{Name: "name", DatabaseType: "VARCHAR"},
+			{Name: "content", DatabaseType: "JSON"},
+			{Name: "created_at", DatabaseType: "DATETIME"},
+			{Name: "updated_at", DatabaseType: "TIMESTAMP"},
+			{Name: "area", DatabaseType: "GEOMETRY"},
+		},
+		Rows: [][]any{
+			{"1", "9.5", "mysql", "{\"a\": 1}", "2024-01-01 10:00:00", "2024-01-01 10:00:00", "raw"},
+			{nil, nil, nil, nil, nil, nil, nil},
+		},
+	}
+	expectTypes := []string{"int64", "float64", "string", "string", "time.Time", "time.Time", "string"}
+
+	allRows, err := DeprecatedScanAnonymousRows(queryFake(t, resultSet))
+	if err != nil {
+		t.Fatalf("DeprecatedScanAnonymousRows() failed: %v", err)
+	}
+	for i, v := range allRows[0] {
+		if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+			t.Errorf("column %d expect %s, got %s", i, expectTypes[i], got)
+		}
+		if allRows[1][i] != nil {
+			t.Errorf("column %d expect untyped nil, got %#v", i, allRows[1][i])
+		}
+	}
+	if createdAt := allRows[0][4].(time.Time); createdAt.Location() != time.UTC {
+		t.Errorf("expect utc time, got %v", createdAt)
+	}
+
+	mappedRows, err := DeprecatedScanAnonymousMappedRows(queryFake(t, resultSet))
+	if err != nil {
+		t.Fatalf("DeprecatedScanAnonymousMappedRows() failed: %v", err)
+	}
+	for i, column := range resultSet.Columns {
+		if got := fmt.Sprintf("%T", mappedRows[0][column.Name]); got != expectTypes[i] {
+			t.Errorf("column %s expect %s, got %s", column.Name, expectTypes[i], got)
+		}
+		if v, ok := mappedRows[1][column.Name]; !ok || v != nil {
+			t.Errorf("column %s expect untyped nil, got %#v", column.Name, v)
+		}
+	}
+}
+
+// TestDeprecatedScanLegacyValueTypes
+// sql rows: [1, 2, 9.5, 'mysql', 1, '2024-01-01 10:00:00', 'raw'], [NULL, 2, NULL, NULL, NULL, NULL, NULL] with scan types of go-sql-driver
+// expect result: with ValueAsPointer, values by scan type as before ValueMode
+// *int64, *string of NOT NULL int64, *float64, **string, bool, utc *time.Time, *string of RawBytes
+// conversion options fail the scan, row limits apply
+func TestDeprecatedScanLegacyValueTypes(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT", ScanType: reflect.TypeOf(sql.NullInt64{})},
+			{Name: "count", DatabaseType: "BIGINT", ScanType: reflect.TypeOf(int64(0)), Nullability: mysqltest.NotNull},
+			{Name: "score", DatabaseType: "DOUBLE", ScanType: reflect.TypeOf(sql.NullFloat64{})},
+			{Name: "name", DatabaseType: "VARCHAR", ScanType: reflect.TypeOf(sql.NullString{})},
+			{Name: "enabled", DatabaseType: "BIT", ScanType: reflect.TypeOf(sql.NullBool{})},
+			{Name: "created_at", DatabaseType: "DATETIME", ScanType: reflect.TypeOf(sql.NullTime{})},
+			{Name: "area", DatabaseType: "GEOMETRY", ScanType: reflect.TypeOf(sql.RawBytes{})},
+		},
+		Rows: [][]any{
+			{"1", "2", "9.5", "mysql", "1", "2024-01-01 10:00:00", "raw"},
+			{nil, "2", nil, nil, nil, nil, nil},
+		},
+	}
+	expectTypes := []string{"*int64", "*string", "*float64", "**string", "bool", "*time.Time", "*string"}
+
+	allRows, err := DeprecatedScanAnonymousRowsWith(queryFake(t, resultSet), WithValueMode(ValueAsPointer))
+	if err != nil {
+		t.Fatalf("DeprecatedScanAnonymousRowsWith() failed: %v", err)
+	}
+	mappedRows, err := DeprecatedScanAnonymousMappedRowsWith(queryFake(t, resultSet), WithValueMode(ValueAsPointer))
+	if err != nil {
+		t.Fatalf("DeprecatedScanAnonymousMappedRowsWith() failed: %v", err)
+	}
+	for i, column := range resultSet.Columns {
+		for _, v := range []any{allRows[0][i], mappedRows[0][column.Name]} {
+			if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+				t.Errorf("column %s expect %s, got %s", column.Name, expectTypes[i], got)
+			}
+		}
+		if column.Nullability == mysqltest.NotNull {
+			continue
+		}
+		for _, v := range []any{allRows[1][i], mappedRows[1][column.Name]} {
+			if column.ScanType == reflect.TypeOf(sql.RawBytes{}) {
+				if raw, ok := v.(*string); !ok || raw != nil {
+					t.Errorf("column %s expect nil *string of NULL, got %#v", column.Name, v)
+				}
+			} else if v != nil {
+				t.Errorf("column %s expect untyped nil of NULL, got %#v", column.Name, v)
+			}
+		}
+	}
+	// conversion options can't apply to converters of scan type
+	for _, opt := range []Option{WithRegistry(NewDefaultRegistry()), WithLocation(time.Local), WithZeroDate(ZeroDateAsNil), WithBinaryEncoding(BinaryAsHex)} {
+		if _, err = DeprecatedScanAnonymousRowsWith(queryFake(t, resultSet), WithValueMode(ValueAsPointer), opt); err == nil {
+			t.Errorf("DeprecatedScanAnonymousRowsWith() expect unsupported option error")
+		}
+	}
+	if _, err = DeprecatedScanAnonymousMappedRowsWith(queryFake(t, resultSet), WithValueMode(ValueAsPointer), WithMaxRows(1)); !isTruncated(err) {
+		t.Errorf("DeprecatedScanAnonymousMappedRowsWith() expect truncated error, got %v", err)
+	}
+	if name := allRows[0][3].(**string); **name != "mysql" {
+		t.Errorf("expect **string mysql, got %v", **name)
+	}
+	if createdAt := allRows[0][5].(*time.Time); createdAt.Location() != time.UTC || createdAt.Hour() != 10 {
+		t.Errorf("expect utc time, got %v", createdAt)
+	}
+}
+
+// TestScanAnonymousRowsValueTypes
+// sql rows: [1, 9.50, '2024-01-01 10:00:00', '2024-01-01 10:00:00', '{"a": 1}', 'mysql']
+// expect result: plain values of ScanAnonymousRows, and with ValueAsPointer of Scan the shapes before ValueMode
+// *int64, *float64 of DECIMAL, time.Time, int64 of TIMESTAMP, *any of JSON, *string
+func TestScanAnonymousRowsValueTypes(t *testing.T) {
+	resultSet := mysqltest.ResultSet{
+		Columns: []mysqltest.Column{
+			{Name: "id", DatabaseType: "BIGINT"},
+			{Name: "price", DatabaseType: "DECIMAL"},
+			{Name: "created_at", DatabaseType: "DATETIME"},
+			{Name: "updated_at", DatabaseType: "TIMESTAMP"},
+			{Name: "content", DatabaseType: "JSON"},
+			{Name: "name", DatabaseType: "VARCHAR"},
+		},
+		Rows: [][]any{{"1", "9.50", "2024-01-01 10:00:00", "2024-01-01 10:00:00", "{\"a\": 1}", "mysql"}},
+	}
+
+	allRows, err := ScanAnonymousRows(queryFake(t, resultSet))
+	if err != nil {
+		t.Fatalf("ScanAnonymousRows() failed: %v", err)
+	}
+	mappedRows, err := ScanAnonymousMappedRows(queryFake(t, resultSet))
+	if err != nil {
+		t.Fatalf("ScanAnonymousMappedRows() failed: %v", err)
+	}
+	expectTypes := []string{"int64", "mysql.Decimal", "time.Time", "int64", "map[string]interface {}", "string"}
+	for i, column := range resultSet.Columns {
+		for _, v := range []any{allRows[0][i], mappedRows[0][column.Name]} {
+			if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+				t.Errorf("column %s expect %s, got %s", column.Name, expectTypes[i], got)
+			}
+		}
+	}
+
+	allRows, err = Scan(queryFake(t, resultSet), WithValueMode(ValueAsPointer))
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	mappedRows, err = ScanMapped(queryFake(t, resultSet), WithValueMode(ValueAsPointer))
+	if err != nil {
+		t.Fatalf("ScanMapped() failed: %v", err)
+	}
+	expectTypes = []string{"*int64", "*float64", "time.Time", "int64", "*interface {}", "*string"}
+	for i, column := range resultSet.Columns {
+		for _, v := range []any{allRows[0][i], mappedRows[0][column.Name]} {
+			if got := fmt.Sprintf("%T", v); got != expectTypes[i] {
+				t.Errorf("column %s expect %s, got %s", column.Name, expectTypes[i], got)
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+/**
+ * Created by zhangruizhi on 2026/10/16
+ */
+
+package mysql
+
+import (
+	"testing"
+)
+
+// TestZeroDatePolicy
+// zero and partial zero dates of DATE, DATETIME and TIMESTAMP converted by each policy
+func TestZeroDatePolicy(t *testing.T) {
+	tests := []struct {
+		mysqlType string
+		in        string
+		policy    ZeroDatePolicy
+		expect    string
+	}{
+		{"DATE", "0000-00-00", ZeroDateAsNil, "null"},
+		{"DATETIME", "0000-00-00 00:00:00", ZeroDateAsNil, "null"},
+		{"TIMESTAMP", "0000-00-00 00:00:00", ZeroDateAsNil, "null"},
+		{"DATE", "0000-00-00", ZeroDateAsZeroTime, "\"0001-01-01T00:00:00Z\""},
+		{"DATETIME", "2024-01-00 10:00:00", ZeroDateAsZeroTime, "\"0001-01-01T00:00:00Z\""},
+		{"TIMESTAMP", "0000-00-00 00:00:00", ZeroDateAsZeroTime, "0"},
+		{"DATE", "2024-00-00", ZeroDateAsString, "\"2024-00-00\""},
+		{"DATETIME", "0000-00-00 00:00:00.000000", ZeroDateAsString, "\"0000-00-00 00:00:00.000000\""},
+	}
+	for _, test := range tests {
+		in := test.in
+		got, err := convertWithOptions(t, test.mysqlType, &in, WithZeroDate(test.policy))
+		if err != nil {
+			t.Errorf("%s %q policy %d failed: %v", test.mysqlType, test.in, test.policy, err)
+			continue
+		}
+		if got != test.expect {
+			t.Errorf("%s %q policy %d expect %s, got %s", test.mysqlType, test.in, test.policy, test.expect, got)
+		}
+	}
+	for _, mysqlType := range []string{"DATE", "DATETIME", "TIMESTAMP"} {
+		in := "0000-00-00 00:00:00"
+		if _, err := convertWithOptions(t, mysqlType, &in); err == nil {
+			t.Errorf("%s zero date with default policy expect error", mysqlType)
+		}
+	}
+	in := "2024-01-01"
+	if got, err := convertWithOptions(t, "DATE", &in, WithZeroDate(ZeroDateAsNil)); err != nil || got == "null" {
+		t.Errorf("DATE non-zero date expect time, got %s %v", got, err)
+	}
+}